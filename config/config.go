@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,31 +13,101 @@ import (
 type Config struct {
 	TelegramBotToken string
 	DBPath           string
+	UsersDBPath      string
 	LogLevel         string
+
+	// Location is the time zone schedules are evaluated in, loaded from
+	// TIMEZONE and falling back to the server's local zone.
+	Location *time.Location
+
+	// AdminAPIAddr is where the admin REST API listens; the API is only
+	// started when AdminAPIToken is set.
+	AdminAPIAddr  string
+	AdminAPIToken string
+
+	// FeedAddr is where the calendar feeds are served, on a listener of
+	// their own so the admin API can stay on a private address. Feeds are
+	// only served when PublicURL, the externally reachable base URL of
+	// that listener used to build feed links, is set.
+	FeedAddr  string
+	PublicURL string
+
+	// HolidaysDir holds the .ics and .json holiday calendars schedules can
+	// subscribe to with /holiday.
+	HolidaysDir string
+
+	// WorkHours is the default range /free looks for free slots in, such
+	// as "08:00-17:00".
+	WorkHours string
 }
 
 func Load() (*Config, error) {
+	cfg, err := LoadOffline()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate required fields
+	if cfg.TelegramBotToken == "" {
+		return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN tidak ditemukan di .env")
+	}
+
+	return cfg, nil
+}
+
+// LoadOffline loads the configuration without requiring a bot token, for
+// CLI commands that only work on the data file.
+func LoadOffline() (*Config, error) {
 	// Load .env file
 	_ = godotenv.Load()
 
 	cfg := &Config{
 		TelegramBotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
 		DBPath:           os.Getenv("DB_PATH"),
+		UsersDBPath:      os.Getenv("USERS_DB_PATH"),
 		LogLevel:         os.Getenv("LOG_LEVEL"),
+		Location:         time.Local,
+		AdminAPIAddr:     os.Getenv("ADMIN_API_ADDR"),
+		AdminAPIToken:    os.Getenv("ADMIN_API_TOKEN"),
+		FeedAddr:         os.Getenv("FEED_ADDR"),
+		PublicURL:        strings.TrimRight(os.Getenv("PUBLIC_URL"), "/"),
+		HolidaysDir:      os.Getenv("HOLIDAYS_DIR"),
+		WorkHours:        os.Getenv("WORK_HOURS"),
 	}
 
-	// Validate required fields
-	if cfg.TelegramBotToken == "" {
-		return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN tidak ditemukan di .env")
+	if tz := os.Getenv("TIMEZONE"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("TIMEZONE '%s' tidak valid: %w", tz, err)
+		}
+		cfg.Location = loc
 	}
 
 	// Set defaults
 	if cfg.DBPath == "" {
 		cfg.DBPath = "./data/schedules.json"
 	}
+	if cfg.UsersDBPath == "" {
+		cfg.UsersDBPath = filepath.Join(filepath.Dir(cfg.DBPath), "users.json")
+	}
+	if cfg.HolidaysDir == "" {
+		cfg.HolidaysDir = filepath.Join(filepath.Dir(cfg.DBPath), "holidays")
+	}
+	if cfg.WorkHours == "" {
+		cfg.WorkHours = "08:00-17:00"
+	}
 	if cfg.LogLevel == "" {
 		cfg.LogLevel = "INFO"
 	}
+	if cfg.AdminAPIAddr == "" {
+		cfg.AdminAPIAddr = "127.0.0.1:8080"
+	}
+	if cfg.FeedAddr == "" {
+		cfg.FeedAddr = "127.0.0.1:8081"
+	}
+	if cfg.AdminAPIToken != "" && cfg.PublicURL != "" && cfg.AdminAPIAddr == cfg.FeedAddr {
+		return nil, fmt.Errorf("ADMIN_API_ADDR dan FEED_ADDR tidak boleh sama (%s)", cfg.FeedAddr)
+	}
 
 	return cfg, nil
 }
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"turschedule/internal/ical"
+	"turschedule/internal/storage"
+)
+
+// Feed serves the read-only calendar feed of each user. It has no admin
+// routes, so it can listen on a public address.
+type Feed struct {
+	storage  *storage.UserSchedules
+	users    *storage.Users
+	holidays *storage.HolidayCalendars
+	location *time.Location
+	mux      *http.ServeMux
+}
+
+func NewFeed(stor *storage.UserSchedules, users *storage.Users, holidays *storage.HolidayCalendars, loc *time.Location) *Feed {
+	f := &Feed{
+		storage:  stor,
+		users:    users,
+		holidays: holidays,
+		location: loc,
+		mux:      http.NewServeMux(),
+	}
+
+	f.mux.HandleFunc("GET /feed/{file}", f.feed)
+
+	return f
+}
+
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the feeds on addr until the listener fails.
+func (f *Feed) ListenAndServe(addr string) error {
+	log.Printf("Feed kalender mendengarkan di %s\n", addr)
+	return http.ListenAndServe(addr, f)
+}
+
+// feed serves the live iCalendar feed of the user owning the token in
+// /feed/{token}.ics: their own schedules and the ones they subscribed to
+// and did not mute. Calendar apps poll it, so it always reflects the
+// current schedules.
+func (f *Feed) feed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	userID, ok := f.users.UserByFeedToken(token)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	schedules := f.storage.GetUserSchedules(userID)
+	for _, s := range f.storage.GetSubscribedSchedules(userID) {
+		if !s.IsMuted(userID) {
+			schedules = append(schedules, s)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Title < schedules[j].Title })
+
+	var buf bytes.Buffer
+	loc := f.location
+	if tz := f.users.Preferences(userID).Timezone; tz != "" {
+		if userLoc, err := time.LoadLocation(tz); err == nil {
+			loc = userLoc
+		}
+	}
+	if err := ical.Encode(&buf, schedules, f.holidays, loc, time.Now()); err != nil {
+		log.Printf("Gagal membuat feed untuk %d: %v\n", userID, err)
+		http.Error(w, "gagal membuat feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="turschedule.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"turschedule/internal/storage"
+)
+
+// Scheduler keeps the live cron registry in sync with storage changes.
+type Scheduler interface {
+	Reschedule(schedule *storage.Schedule)
+	Remove(schedule *storage.Schedule)
+}
+
+// Server exposes the admin REST API for managing schedules of any user.
+type Server struct {
+	storage   *storage.UserSchedules
+	users     *storage.Users
+	scheduler Scheduler
+	token     string
+	mux       *http.ServeMux
+}
+
+func NewServer(stor *storage.UserSchedules, users *storage.Users, scheduler Scheduler, token string) *Server {
+	s := &Server{
+		storage:   stor,
+		users:     users,
+		scheduler: scheduler,
+		token:     token,
+		mux:       http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /api/schedules", s.admin(s.listSchedules))
+	s.mux.HandleFunc("POST /api/schedules", s.admin(s.createSchedule))
+	s.mux.HandleFunc("GET /api/schedules/{id}", s.admin(s.getSchedule))
+	s.mux.HandleFunc("PUT /api/schedules/{id}", s.admin(s.updateSchedule))
+	s.mux.HandleFunc("DELETE /api/schedules/{id}", s.admin(s.deleteSchedule))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the API on addr until the listener fails.
+func (s *Server) ListenAndServe(addr string) error {
+	log.Printf("Admin API mendengarkan di %s\n", addr)
+	return http.ListenAndServe(addr, s)
+}
+
+// admin rejects requests that do not carry the configured bearer token.
+func (s *Server) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("token tidak valid"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// scheduleRequest holds the writable fields of a schedule.
+type scheduleRequest struct {
+	UserID        int64    `json:"user_id"`
+	Title         string   `json:"title"`
+	Time          string   `json:"time"`
+	Duration      int      `json:"duration"`
+	Days          []string `json:"days"`
+	Note          string   `json:"note"`
+	Tags          []string `json:"tags"`
+	ReminderType  string   `json:"reminder_type"`
+	ReminderTimes []int    `json:"reminder_times"`
+	QuietMode     string   `json:"quiet_mode"`
+	NagEvery      int      `json:"nag_every"`
+	NagMax        int      `json:"nag_max"`
+}
+
+func (req *scheduleRequest) apply(schedule *storage.Schedule) {
+	schedule.UserID = req.UserID
+	schedule.Title = req.Title
+	schedule.Time = req.Time
+	schedule.Duration = req.Duration
+	schedule.Days = req.Days
+	schedule.Note = req.Note
+	schedule.Tags = req.Tags
+	schedule.ReminderType = req.ReminderType
+	schedule.ReminderTimes = req.ReminderTimes
+	schedule.QuietMode = req.QuietMode
+	schedule.NagEvery = req.NagEvery
+	schedule.NagMax = req.NagMax
+	if schedule.NagEvery == 0 {
+		schedule.PendingNag = nil
+	}
+}
+
+func (s *Server) listSchedules(w http.ResponseWriter, r *http.Request) {
+	var schedules []*storage.Schedule
+	if raw := r.URL.Query().Get("user_id"); raw != "" {
+		userID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("user_id '%s' tidak valid", raw))
+			return
+		}
+		schedules = s.storage.GetUserSchedules(userID)
+	} else {
+		schedules = s.storage.GetAllSchedules()
+	}
+
+	if schedules == nil {
+		schedules = []*storage.Schedule{}
+	}
+	writeJSON(w, http.StatusOK, schedules)
+}
+
+func (s *Server) getSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := s.storage.GetSchedule(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, schedule)
+}
+
+func (s *Server) createSchedule(w http.ResponseWriter, r *http.Request) {
+	var req scheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("body JSON tidak valid: %w", err))
+		return
+	}
+	if req.UserID == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("user_id wajib diisi"))
+		return
+	}
+	prefs := s.users.Preferences(req.UserID)
+	if req.ReminderType == "" {
+		req.ReminderType = prefs.DefaultType()
+	}
+	if req.ReminderTimes == nil {
+		req.ReminderTimes = prefs.DefaultTimes()
+	}
+
+	schedule := &storage.Schedule{ReminderSent: make(map[string]bool)}
+	req.apply(schedule)
+	if err := schedule.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if s.storage.IsTitleExists(schedule.UserID, schedule.Title) {
+		writeError(w, http.StatusConflict, fmt.Errorf("judul '%s' sudah ada", schedule.Title))
+		return
+	}
+
+	if err := s.storage.AddSchedule(schedule); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.scheduler.Reschedule(schedule)
+
+	writeJSON(w, http.StatusCreated, schedule)
+}
+
+func (s *Server) updateSchedule(w http.ResponseWriter, r *http.Request) {
+	current, err := s.storage.GetSchedule(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	// Start from the stored values so partial bodies only touch the fields
+	// they carry.
+	req := scheduleRequest{
+		UserID:        current.UserID,
+		Title:         current.Title,
+		Time:          current.Time,
+		Duration:      current.Duration,
+		Days:          current.Days,
+		Note:          current.Note,
+		Tags:          current.Tags,
+		ReminderType:  current.ReminderType,
+		ReminderTimes: current.ReminderTimes,
+		QuietMode:     current.QuietMode,
+		NagEvery:      current.NagEvery,
+		NagMax:        current.NagMax,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("body JSON tidak valid: %w", err))
+		return
+	}
+
+	updated := *current
+	req.apply(&updated)
+	if err := updated.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if (updated.Title != current.Title || updated.UserID != current.UserID) &&
+		s.storage.IsTitleExists(updated.UserID, updated.Title) {
+		writeError(w, http.StatusConflict, fmt.Errorf("judul '%s' sudah ada", updated.Title))
+		return
+	}
+
+	if err := s.storage.UpdateSchedule(&updated); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.scheduler.Reschedule(&updated)
+
+	writeJSON(w, http.StatusOK, &updated)
+}
+
+func (s *Server) deleteSchedule(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	schedule, err := s.storage.GetSchedule(id)
+	if err == nil {
+		err = s.storage.DeleteSchedule(id)
+	}
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	s.scheduler.Remove(schedule)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Gagal menulis respons: %v\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// defaultNextCount is how many upcoming occurrences /next shows without an
+// argument; maxNextCount keeps the reply within one Telegram message.
+const (
+	defaultNextCount = 5
+	maxNextCount     = 30
+)
+
+// occurrence is a single firing of a schedule. Holiday names the holiday
+// that skips it, if any.
+type occurrence struct {
+	At       time.Time
+	Schedule *storage.Schedule
+	Holiday  string
+}
+
+// occurrencesBetween collects the firings of chatID's schedules tagged tag
+// in [from, to), sorted chronologically.
+func (b *Bot) occurrencesBetween(chatID int64, from, to time.Time, tag string) []occurrence {
+	var result []occurrence
+	for _, s := range filterByTag(b.storage.GetUserSchedules(chatID), tag) {
+		for _, at := range s.OccurrencesBetween(from, to) {
+			holiday, _ := b.holidays.Holiday(s, at)
+			result = append(result, occurrence{At: at, Schedule: s, Holiday: holiday})
+		}
+	}
+	sortOccurrences(result)
+	return result
+}
+
+// nextOccurrences returns the first n firings after now across chatID's
+// schedules tagged tag.
+func (b *Bot) nextOccurrences(chatID int64, now time.Time, n int, tag string) []occurrence {
+	var result []occurrence
+	for _, s := range filterByTag(b.storage.GetUserSchedules(chatID), tag) {
+		for _, at := range s.NextOccurrences(now, n) {
+			holiday, _ := b.holidays.Holiday(s, at)
+			result = append(result, occurrence{At: at, Schedule: s, Holiday: holiday})
+		}
+	}
+	sortOccurrences(result)
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+func sortOccurrences(occurrences []occurrence) {
+	sort.Slice(occurrences, func(i, j int) bool {
+		if !occurrences[i].At.Equal(occurrences[j].At) {
+			return occurrences[i].At.Before(occurrences[j].At)
+		}
+		return occurrences[i].Schedule.Title < occurrences[j].Schedule.Title
+	})
+}
+
+// sendAgenda handles /today, /tomorrow and /week, each with an optional
+// #tag filter.
+func (b *Bot) sendAgenda(chatID int64, period string, args []string) {
+	lang := b.lang(chatID)
+	tag, _, err := splitTagFilter(args)
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	filter := ""
+	if tag != "" {
+		filter = i18n.T(lang, "tag.filter", esc(tag))
+	}
+	now := time.Now().In(b.loc(chatID))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var (
+		from, to time.Time
+		header   string
+	)
+	switch period {
+	case "today":
+		from, to = today, today.AddDate(0, 0, 1)
+		header = i18n.T(lang, "agenda.today", i18n.FormatWeekdayDate(lang, from))
+	case "tomorrow":
+		from, to = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+		header = i18n.T(lang, "agenda.tomorrow", i18n.FormatWeekdayDate(lang, from))
+	case "week":
+		from, to = now, today.AddDate(0, 0, 7)
+		header = i18n.T(lang, "agenda.week")
+	}
+
+	occurrences := b.occurrencesBetween(chatID, from, to, tag)
+	if len(occurrences) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "agenda.empty."+period)+"\n"+filter)
+		return
+	}
+
+	// Only the week view spans several days and needs date headings
+	b.sendMessage(chatID, header+filter+renderOccurrences(lang, occurrences, now, period == "week"))
+}
+
+// sendNext handles /next [count] [#tag].
+func (b *Bot) sendNext(chatID int64, args []string) {
+	lang := b.lang(chatID)
+	tag, args, err := splitTagFilter(args)
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	filter := ""
+	if tag != "" {
+		filter = i18n.T(lang, "tag.filter", esc(tag))
+	}
+	count := defaultNextCount
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > maxNextCount {
+			b.sendMessage(chatID, i18n.T(lang, "agenda.next.invalid"))
+			return
+		}
+		count = n
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	occurrences := b.nextOccurrences(chatID, now, count, tag)
+	if len(occurrences) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "agenda.empty.next")+"\n"+filter)
+		return
+	}
+
+	header := i18n.N(lang, "agenda.next", len(occurrences), len(occurrences))
+	b.sendMessage(chatID, header+filter+renderOccurrences(lang, occurrences, now, true))
+}
+
+// countdown describes how far away an occurrence is, e.g. "dalam 2 jam 15
+// menit".
+func countdown(lang i18n.Lang, d time.Duration) string {
+	switch {
+	case d < 0:
+		return i18n.T(lang, "agenda.past")
+	case d < time.Minute:
+		return i18n.T(lang, "agenda.soon")
+	}
+	return i18n.T(lang, "agenda.in", i18n.Duration(lang, d))
+}
@@ -4,18 +4,58 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/robfig/cron/v3"
+	"turschedule/config"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
 	"turschedule/internal/storage"
 )
 
 type Bot struct {
 	api       *tgbotapi.BotAPI
 	storage   *storage.UserSchedules
+	users     *storage.Users
 	cron      *cron.Cron
-	userState map[int64]UserState
+	location  *time.Location
+	publicURL string
+	userState map[stateKey]UserState
+	holidays  *storage.HolidayCalendars
+
+	// zones caches the time zones chats chose in /settings by name.
+	zones sync.Map
+
+	// defaultWorkHours is where /free looks for free slots in chats that
+	// did not set their own working hours.
+	defaultWorkHours storage.Slot
+
+	// jobs maps a schedule ID to the cron entries registered for it so the
+	// entries can be replaced when the schedule is edited or deleted.
+	jobs   map[string][]cron.EntryID
+	jobsMu sync.Mutex
+
+	// delayed marks the reminders held until quiet hours end, by schedule,
+	// chat and occurrence date, so each occurrence gets one message. It is
+	// guarded by jobsMu.
+	delayed map[string]bool
+
+	// undo holds the last /bulk action of each chat until it is undone.
+	undo map[int64]bulkUndo
+
+	// loadOnce registers the stored schedules a single time, whether from
+	// Load or Start.
+	loadOnce sync.Once
+}
+
+// stateKey identifies a conversation with the bot. In a group every member
+// runs their own wizard, so state is kept per chat and sender; in a private
+// chat both IDs are the same.
+type stateKey struct {
+	ChatID int64
+	UserID int64
 }
 
 type UserState struct {
@@ -23,30 +63,54 @@ type UserState struct {
 	Data   map[string]interface{}
 }
 
-func NewBot(token string, dbPath string) (*Bot, error) {
-	api, err := tgbotapi.NewBotAPI(token)
+func NewBot(cfg *config.Config, stor *storage.UserSchedules, users *storage.Users, holidays *storage.HolidayCalendars) (*Bot, error) {
+	api, err := tgbotapi.NewBotAPI(cfg.TelegramBotToken)
 	if err != nil {
 		return nil, fmt.Errorf("gagal membuat bot API: %w", err)
 	}
 
-	stor, err := storage.NewUserSchedules(dbPath)
+	workHours, err := storage.ParseSlot(cfg.WorkHours)
 	if err != nil {
-		return nil, fmt.Errorf("gagal menginisialisasi storage: %w", err)
+		return nil, fmt.Errorf("WORK_HOURS tidak valid: %w", err)
 	}
 
 	bot := &Bot{
-		api:       api,
-		storage:   stor,
-		cron:      cron.New(),
-		userState: make(map[int64]UserState),
+		api:              api,
+		storage:          stor,
+		users:            users,
+		cron:             cron.New(cron.WithLocation(cfg.Location)),
+		location:         cfg.Location,
+		publicURL:        cfg.PublicURL,
+		userState:        make(map[stateKey]UserState),
+		holidays:         holidays,
+		defaultWorkHours: workHours,
+		jobs:             make(map[string][]cron.EntryID),
+		delayed:          make(map[string]bool),
+		undo:             make(map[int64]bulkUndo),
 	}
 
 	log.Printf("Bot %s sudah aktif\n", api.Self.UserName)
 	return bot, nil
 }
 
+// Load registers the cron jobs of the stored schedules, digests and pending
+// nags. Call it before anything else can reschedule a schedule, such as the
+// admin API; Start calls it too if it has not run yet.
+func (b *Bot) Load() {
+	b.loadOnce.Do(func() {
+		for _, schedule := range b.storage.GetAllSchedules() {
+			b.scheduleReminder(schedule)
+		}
+		for _, chatID := range b.users.DigestUsers() {
+			b.scheduleDigest(chatID)
+		}
+		b.resumeNags()
+	})
+}
+
 func (b *Bot) Start() error {
-	// Start cron scheduler
+	// Register stored schedules, then start cron scheduler
+	b.Load()
 	b.cron.Start()
 
 	u := tgbotapi.NewUpdate(0)
@@ -55,115 +119,313 @@ func (b *Bot) Start() error {
 	updates := b.api.GetUpdatesChan(u)
 
 	for update := range updates {
-		if update.Message == nil {
+		if update.CallbackQuery != nil {
+			if update.CallbackQuery.Message != nil {
+				b.detectLanguage(update.CallbackQuery.Message.Chat.ID, update.CallbackQuery.From)
+			}
+			b.handleCallback(update.CallbackQuery)
+			continue
+		}
+		if update.Message == nil || update.Message.From == nil {
 			continue
 		}
 
-		userID := update.Message.Chat.ID
+		// Schedules and the interface language belong to the chat, the
+		// conversation state to the member writing in it
+		chatID := update.Message.Chat.ID
+		from := update.Message.From
 		text := update.Message.Text
+		b.detectLanguage(chatID, from)
+		b.rememberUsername(chatID, from)
 
-		if strings.HasPrefix(text, "/") {
-			b.handleCommand(userID, text)
+		if update.Message.Document != nil {
+			b.handleDocument(chatID, from, update.Message.Document)
+		} else if strings.HasPrefix(text, "/") {
+			b.handleCommand(chatID, from, text)
 		} else {
-			b.handleMessage(userID, text)
+			b.handleMessage(chatID, from, text)
 		}
 	}
 
 	return nil
 }
 
-func (b *Bot) handleCommand(userID int64, command string) {
+func (b *Bot) handleCommand(chatID int64, from *tgbotapi.User, command string) {
 	parts := strings.Fields(command)
-	cmd := parts[0]
+	// In groups commands may be addressed as /add@BotName; ignore the ones
+	// meant for other bots
+	cmd, bot, _ := strings.Cut(parts[0], "@")
+	if bot != "" && !strings.EqualFold(bot, b.api.Self.UserName) {
+		return
+	}
+	lang := b.lang(chatID)
+	k := stateKey{chatID, from.ID}
 
 	switch cmd {
 	case "/start":
-		b.sendMessage(userID, getHelpText())
+		if len(parts) > 1 && strings.HasPrefix(parts[1], sharePrefix) {
+			b.openInvite(chatID, strings.TrimPrefix(parts[1], sharePrefix))
+			return
+		}
+		b.sendMessage(chatID, i18n.T(lang, "help"))
 
 	case "/add":
-		b.sendMessageWithKeyboard(userID, "Masukkan nama jadwal:", getSkipKeyboard())
-		b.userState[userID] = UserState{
+		if len(parts) > 1 {
+			b.startDraft(chatID, from, strings.TrimSpace(strings.TrimPrefix(command, parts[0])))
+			return
+		}
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.title"), getSkipKeyboard(lang))
+		b.userState[k] = UserState{
 			Action: "add_title",
 			Data:   make(map[string]interface{}),
 		}
 
 	case "/list":
-		b.listSchedules(userID)
+		tag, args, err := splitTagFilter(parts[1:])
+		if err != nil {
+			b.sendMessage(chatID, renderError(lang, err))
+			return
+		}
+		sortBy := listSortNext
+		if len(args) > 0 {
+			sortBy = args[0]
+		}
+		if !isListSort(sortBy) {
+			b.sendMessage(chatID, i18n.T(lang, "list.sort.invalid"))
+			return
+		}
+		b.sendList(chatID, sortBy, tag, 0, 0)
 
 	case "/edit":
-		schedules := b.storage.GetUserSchedules(userID)
+		if !b.requireAdmin(chatID, from.ID) {
+			return
+		}
+		schedules := b.storage.GetUserSchedules(chatID)
 		if len(schedules) == 0 {
-			b.sendMessage(userID, "Anda belum memiliki jadwal. Gunakan /add untuk membuat jadwal baru.")
+			b.sendMessage(chatID, i18n.T(lang, "schedules.none"))
 			return
 		}
-		
-		b.listSchedules(userID)
-		b.sendMessage(userID, "Masukkan judul jadwal yang ingin diubah:")
-		b.userState[userID] = UserState{
+
+		b.sendList(chatID, listSortNext, "", 0, 0)
+		b.sendMessage(chatID, i18n.T(lang, "ask.edit_title"))
+		b.userState[k] = UserState{
 			Action: "edit_title",
 			Data:   make(map[string]interface{}),
 		}
 
 	case "/delete":
-		schedules := b.storage.GetUserSchedules(userID)
+		if !b.requireAdmin(chatID, from.ID) {
+			return
+		}
+		schedules := b.storage.GetUserSchedules(chatID)
 		if len(schedules) == 0 {
-			b.sendMessage(userID, "Anda belum memiliki jadwal. Gunakan /add untuk membuat jadwal baru.")
+			b.sendMessage(chatID, i18n.T(lang, "schedules.none"))
 			return
 		}
-		
-		b.listSchedules(userID)
-		b.sendMessage(userID, "Masukkan judul jadwal yang ingin dihapus:")
-		b.userState[userID] = UserState{
+
+		b.sendList(chatID, listSortNext, "", 0, 0)
+		b.sendMessage(chatID, i18n.T(lang, "ask.delete_title"))
+		b.userState[k] = UserState{
 			Action: "delete_title",
 			Data:   make(map[string]interface{}),
 		}
 
+	case "/today":
+		b.sendAgenda(chatID, "today", parts[1:])
+
+	case "/tomorrow":
+		b.sendAgenda(chatID, "tomorrow", parts[1:])
+
+	case "/week":
+		b.sendAgenda(chatID, "week", parts[1:])
+
+	case "/next":
+		b.sendNext(chatID, parts[1:])
+
+	case "/share":
+		b.shareSchedule(chatID, from, parts[1:])
+
+	case "/shared":
+		b.sendShared(chatID, 0)
+
+	case "/rsvp":
+		b.sendRSVP(chatID, parts[1:])
+
+	case "/export":
+		b.exportSchedules(chatID, parts[1:])
+
+	case "/pause":
+		b.pauseSchedule(chatID, from, parts[1:])
+
+	case "/resume":
+		b.resumeSchedule(chatID, from, parts[1:])
+
+	case "/skip":
+		b.skipNext(chatID, from, parts[1:])
+
+	case "/bulk":
+		b.startBulk(chatID, from, parts[1:])
+
+	case "/duplicate":
+		b.startDuplicate(chatID, from, parts[1:])
+
+	case "/template":
+		b.templateCommand(chatID, from, parts[1:])
+
+	case "/stats":
+		b.sendStats(chatID, parts[1:])
+
+	case "/priority":
+		b.setPriority(chatID, from, parts[1:])
+
+	case "/quiet":
+		b.setQuietMode(chatID, from, parts[1:])
+
+	case "/digest":
+		b.sendDigestSettings(chatID, 0)
+
+	case "/settings":
+		b.sendSettings(chatID, 0)
+
+	case "/free":
+		b.sendFree(chatID, parts[1:])
+
+	case "/workhours":
+		b.setWorkHours(chatID, from, parts[1:])
+
+	case "/holiday":
+		b.holidayCalendar(chatID, from, parts[1:])
+
+	case "/mute", "/unmute":
+		b.muteTag(chatID, from, parts[1:], cmd == "/mute")
+
+	case "/feed":
+		reset := len(parts) > 1 && parts[1] == "reset"
+		if reset && !b.requireAdmin(chatID, from.ID) {
+			return
+		}
+		b.sendFeed(chatID, reset)
+
+	case "/language":
+		if !b.requireAdmin(chatID, from.ID) {
+			return
+		}
+		if len(parts) > 1 {
+			b.setLanguage(chatID, parts[1])
+			return
+		}
+		b.userState[k] = UserState{Action: "language_select", Data: make(map[string]interface{})}
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "language.prompt"), getLanguageKeyboard(lang))
+
 	case "/help":
-		b.sendMessage(userID, getHelpText())
+		b.sendMessage(chatID, i18n.T(lang, "help"))
 
 	default:
-		b.sendMessage(userID, "Perintah tidak dikenal. Ketik /help untuk bantuan.")
+		b.sendMessage(chatID, i18n.T(lang, "command.unknown"))
+	}
+}
+
+// handleCallback acknowledges an inline button press and hands it to the
+// handler of its prefix.
+func (b *Bot) handleCallback(cq *tgbotapi.CallbackQuery) {
+	if cq.Message == nil {
+		return
+	}
+	chatID := cq.Message.Chat.ID
+	messageID := cq.Message.MessageID
+	if cq.From == nil {
+		return
+	}
+
+	// Acknowledge so the client stops showing the loading indicator
+	b.api.Request(tgbotapi.NewCallback(cq.ID, ""))
+
+	prefix, value, _ := strings.Cut(cq.Data, ":")
+	switch prefix {
+	case "time":
+		b.handleTimePicker(chatID, cq.From, messageID, value)
+	case "list":
+		b.handleListCallback(chatID, messageID, value)
+	case "sch":
+		b.handleScheduleCallback(chatID, cq.From, messageID, value)
+	case "shr":
+		b.handleShareCallback(chatID, cq.From, messageID, value)
+	case "rsvp":
+		b.handleRSVPCallback(chatID, cq.From, cq.Message, value)
+	case "digest":
+		b.handleDigestCallback(chatID, cq.From, messageID, value)
+	case "done":
+		b.handleDoneCallback(chatID, cq.From, messageID, value)
+	case "set":
+		b.handleSettingsCallback(chatID, cq.From, value)
+	case "blk":
+		b.handleBulkCallback(chatID, cq.From, messageID, value)
+	case "tpl":
+		b.handleTemplateCallback(chatID, cq.From, messageID, value)
 	}
 }
 
-func (b *Bot) handleMessage(userID int64, text string) {
-	state, exists := b.userState[userID]
+func (b *Bot) handleMessage(chatID int64, from *tgbotapi.User, text string) {
+	lang := b.lang(chatID)
+	k := stateKey{chatID, from.ID}
+	state, exists := b.userState[k]
 	if !exists {
-		b.sendMessage(userID, "Ketik /help untuk bantuan.")
+		// Group members talk to each other; only answer them inside a flow
+		if !isPrivate(chatID) {
+			return
+		}
+		// Outside a flow, treat text that mentions a time as a schedule
+		if nlp.Parse(text, time.Now().In(b.loc(chatID))).Time != "" {
+			b.startDraft(chatID, from, text)
+			return
+		}
+		b.sendMessage(chatID, i18n.T(lang, "help.hint"))
 		return
 	}
 
 	// Handle cancel button
-	if text == "❌ Batal" {
-		delete(b.userState, userID)
-		b.sendMessage(userID, "Dibatalkan. Ketik /help untuk bantuan.")
+	if i18n.Match(text, "button.cancel") {
+		delete(b.userState, k)
+		b.sendMessage(chatID, i18n.T(lang, "cancelled"))
 		return
 	}
 
 	switch state.Action {
 	case "add_title":
 		state.Data["title"] = text
-		
+
 		// Check if title already exists for this user
-		if b.storage.IsTitleExists(userID, text) {
-			b.sendReplyMessage(userID, "❌ Judul sudah ada. Gunakan judul yang berbeda.")
-			b.sendMessageWithKeyboard(userID, "Masukkan nama jadwal:", getSkipKeyboard())
+		if b.storage.IsTitleExists(chatID, text) {
+			b.sendReplyMessage(chatID, i18n.T(lang, "title.exists"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.title"), getSkipKeyboard(lang))
 			return
 		}
-		
+
 		state.Action = "add_time"
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "Pilih waktu:", getTimeKeyboard())
+		b.userState[k] = state
+		b.askTime(chatID, i18n.T(lang, "ask.time"))
 
 	case "add_time":
-		if !isValidTime(text) {
-			b.sendReplyMessage(userID, "Format waktu tidak valid. Gunakan HH:MM (contoh: 09:30)")
+		clock, ok := nlp.ParseTime(text)
+		if !ok {
+			b.sendReplyMessage(chatID, i18n.T(lang, "time.invalid"))
 			return
 		}
-		state.Data["time"] = text
+		state.Data["time"] = clock
+		state.Action = "add_duration"
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.duration"), getDurationKeyboard(lang))
+
+	case "add_duration":
+		duration, ok := parseDurationInput(text, state.Data["time"].(string))
+		if !ok {
+			b.sendReplyMessage(chatID, i18n.T(lang, "duration.invalid"))
+			return
+		}
+		state.Data["duration"] = duration
 		state.Action = "add_days"
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "Pilih hari (bisa pilih lebih dari satu):", getDaysKeyboard())
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.days"), getDaysKeyboard(lang))
 
 	case "add_days":
 		// Initialize days list if not exists
@@ -173,24 +435,24 @@ func (b *Bot) handleMessage(userID int64, text string) {
 		}
 
 		// Handle "Selesai Pilih" button
-		if text == "🔄 Selesai Pilih" || text == "selesai pilih" || text == "Selesai Pilih" {
+		if i18n.Match(text, "button.days_done") {
 			if len(selectedDays) == 0 {
-				b.sendReplyMessage(userID, "Pilih minimal satu hari!")
-				b.sendMessageWithKeyboard(userID, "Pilih hari (bisa pilih lebih dari satu):", getDaysKeyboard())
+				b.sendReplyMessage(chatID, i18n.T(lang, "days.min_one"))
+				b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.days"), getDaysKeyboard(lang))
 				return
 			}
 			state.Data["days"] = selectedDays
 			state.Data["selectedDays"] = nil // Reset selected days
 			state.Action = "add_note"
-			b.userState[userID] = state
-			b.sendMessageWithKeyboard(userID, "Masukkan catatan (opsional, atau ketik '-'):", getNoteKeyboard())
+			b.userState[k] = state
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.note"), getNoteKeyboard(lang))
 			return
 		}
 
 		// Parse selected day
 		days := parsedays(text)
 		if len(days) == 0 {
-			b.sendReplyMessage(userID, "Format hari tidak valid. Pilih dari tombol yang tersedia.")
+			b.sendReplyMessage(chatID, i18n.T(lang, "days.invalid_pick"))
 			return
 		}
 
@@ -198,267 +460,424 @@ func (b *Bot) handleMessage(userID int64, text string) {
 		newDay := days[0]
 		for _, d := range selectedDays {
 			if d == newDay {
-				b.sendReplyMessage(userID, "Hari sudah dipilih. Pilih hari lain atau tekan 🔄 Selesai Pilih")
-				b.sendMessageWithKeyboard(userID, "Hari yang dipilih: "+strings.Join(selectedDays, ", "), getDaysKeyboard())
+				b.sendReplyMessage(chatID, i18n.T(lang, "days.already", i18n.T(lang, "button.days_done")))
+				b.sendMessageWithKeyboard(chatID, i18n.T(lang, "days.selected", i18n.DayNames(lang, selectedDays)), getDaysKeyboard(lang))
 				return
 			}
 		}
 
 		selectedDays = append(selectedDays, newDay)
 		state.Data["selectedDays"] = selectedDays
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "✅ "+newDay+" dipilih\n\nHari yang dipilih: "+strings.Join(selectedDays, ", "), getDaysKeyboard())
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "days.picked", i18n.DayName(lang, newDay))+"\n\n"+i18n.T(lang, "days.selected", i18n.DayNames(lang, selectedDays)), getDaysKeyboard(lang))
 
 	case "add_note":
 		note := text
-		if note == "-" || note == "Tidak ada catatan" {
+		if note == "-" || i18n.Match(note, "button.no_note") {
 			note = ""
 		}
 		state.Data["note"] = note
+		state.Action = "add_tags"
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.tags"), getTagsKeyboard(lang, b.storage.GetUserTags(chatID)))
+
+	case "add_tags":
+		var tags []string
+		if text != "-" && !i18n.Match(text, "button.no_tags") {
+			var err error
+			if tags, err = storage.ParseTags(text); err != nil {
+				b.sendReplyMessage(chatID, renderError(lang, err))
+				return
+			}
+		}
+		state.Data["tags"] = tags
 		state.Action = "add_reminder_type"
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "Pilih tipe reminder:", getReminderTypeKeyboard())
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.reminder_type"), getReminderTypeKeyboard(lang))
 
 	case "add_reminder_type":
-		reminderType := strings.ToLower(text)
-		if !contains([]string{"🔔 Sekali", "🔊 Berkali-kali", "sekali", "berkali-kali"}, text) {
-			b.sendReplyMessage(userID, "Pilihan tidak valid. Pilih dari tombol yang tersedia.")
-			return
-		}
-
-		if reminderType == "🔔 sekali" || reminderType == "sekali" {
+		if i18n.Match(text, "button.once") {
 			state.Data["reminderType"] = "once"
-		} else if reminderType == "🔊 berkali-kali" || reminderType == "berkali-kali" {
+		} else if i18n.Match(text, "button.recurring") {
 			state.Data["reminderType"] = "recurring"
-		}
-
-		// Create schedule with reminder settings
-		schedule := &storage.Schedule{
-			ID:            fmt.Sprintf("%d_%d", userID, time.Now().Unix()),
-			UserID:        userID,
-			Title:         state.Data["title"].(string),
-			Time:          state.Data["time"].(string),
-			Days:          state.Data["days"].([]string),
-			Note:          state.Data["note"].(string),
-			ReminderType:  state.Data["reminderType"].(string),
-			ReminderTimes: []int{60, 30, 5}, // Default: 1 jam, 30 menit, 5 menit sebelum
-			ReminderSent:  make(map[string]bool),
-		}
-
-		if err := b.storage.AddSchedule(schedule); err != nil {
-			b.sendMessage(userID, fmt.Sprintf("Error: %v", err))
 		} else {
-			typeStr := "Berkali-kali"
-			if schedule.ReminderType == "once" {
-				typeStr = "Sekali"
-			}
-			b.sendMessage(userID, fmt.Sprintf("✅ Jadwal berhasil ditambahkan!\n📌 %s\n⏰ Reminder: %s (1h, 30m, 5m sebelum waktu yang ditentukan)", schedule.Title, typeStr))
-			b.scheduleReminder(schedule)
+			b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+			return
 		}
 
-		delete(b.userState, userID)
+		b.createSchedule(chatID, from, state.Data)
+		delete(b.userState, k)
 
 	case "edit_id":
-		schedule, err := b.storage.GetScheduleByTitle(userID, text)
+		schedule, err := b.storage.GetScheduleByTitle(chatID, text)
 		if err != nil {
-			b.sendMessage(userID, "Schedule tidak ditemukan.")
-			delete(b.userState, userID)
+			b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+			delete(b.userState, k)
 			return
 		}
 
 		state.Data["schedule"] = schedule
 		state.Action = "edit_field"
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "Pilih field yang ingin diubah:", getFieldKeyboard())
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getFieldKeyboard(lang))
 
 	case "edit_title":
-		schedule, err := b.storage.GetScheduleByTitle(userID, text)
+		schedule, err := b.storage.GetScheduleByTitle(chatID, text)
 		if err != nil {
-			b.sendMessage(userID, "❌ Jadwal dengan judul tersebut tidak ditemukan.")
-			delete(b.userState, userID)
+			b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+			delete(b.userState, k)
 			return
 		}
 
 		state.Data["schedule"] = schedule
 		state.Action = "edit_field"
-		b.userState[userID] = state
-		b.sendMessageWithKeyboard(userID, "Pilih field yang ingin diubah:", getFieldKeyboard())
+		b.userState[k] = state
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getFieldKeyboard(lang))
 
 	case "edit_field":
-		// Use fieldMap to convert button text to field name
-		fieldMap := map[string]string{
-			"1": "title", "2": "time", "3": "days", "4": "note",
-			"1️⃣ Title": "title", "2️⃣ Waktu": "time", "3️⃣ Hari": "days", "4️⃣ Catatan": "note",
-		}
-		
+		// Convert the button text, or its number, to the field name
 		field := ""
-		if f, exists := fieldMap[text]; exists {
-			field = f
-		} else {
-			b.sendReplyMessage(userID, "Field tidak valid. Pilih dari tombol yang tersedia.")
-			b.sendMessageWithKeyboard(userID, "Pilih field yang ingin diubah:", getFieldKeyboard())
+		for i, f := range []string{"title", "time", "days", "note", "tags", "duration", "quiet"} {
+			if text == fmt.Sprint(i+1) || i18n.Match(text, "button.field."+f) {
+				field = f
+				break
+			}
+		}
+		if field == "" {
+			b.sendReplyMessage(chatID, i18n.T(lang, "field.invalid"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getFieldKeyboard(lang))
 			return
 		}
 
 		state.Data["field"] = field
 		state.Action = "edit_value"
-		b.userState[userID] = state
-		
+		b.userState[k] = state
+
+		fieldName := i18n.T(lang, "field."+field)
 		switch field {
 		case "title":
-			b.sendMessageWithKeyboard(userID, fmt.Sprintf("Masukkan nilai baru untuk %s:", field), getSkipKeyboard())
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.new_value", fieldName), getSkipKeyboard(lang))
 		case "time":
-			b.sendMessageWithKeyboard(userID, fmt.Sprintf("Pilih nilai baru untuk %s:", field), getTimeKeyboard())
+			b.askTime(chatID, i18n.T(lang, "ask.pick_value", fieldName))
 		case "days":
-			b.sendMessageWithKeyboard(userID, fmt.Sprintf("Pilih nilai baru untuk %s:", field), getDaysKeyboard())
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.pick_value", fieldName), getDaysKeyboard(lang))
 		case "note":
-			b.sendMessageWithKeyboard(userID, fmt.Sprintf("Masukkan catatan:", field), getNoteKeyboard())
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.note_edit"), getNoteKeyboard(lang))
+		case "tags":
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.tags"), getTagsKeyboard(lang, b.storage.GetUserTags(chatID)))
+		case "duration":
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.duration"), getDurationKeyboard(lang))
+		case "quiet":
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.quiet_mode"), getQuietModeKeyboard(lang))
 		}
 
 	case "edit_value":
-		schedule := state.Data["schedule"].(*storage.Schedule)
+		// Change a copy: the stored schedule is read by running reminders
+		updated := *state.Data["schedule"].(*storage.Schedule)
+		schedule := &updated
 		field := state.Data["field"].(string)
 
 		switch field {
 		case "title":
 			// Check if new title already exists (but allow same title)
-			if text != schedule.Title && b.storage.IsTitleExists(userID, text) {
-				b.sendReplyMessage(userID, "❌ Judul sudah digunakan. Gunakan judul yang berbeda.")
-				b.sendMessageWithKeyboard(userID, "Masukkan judul baru:", getSkipKeyboard())
+			if text != schedule.Title && b.storage.IsTitleExists(chatID, text) {
+				b.sendReplyMessage(chatID, i18n.T(lang, "title.exists"))
+				b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.new_title"), getSkipKeyboard(lang))
 				return
 			}
 			schedule.Title = text
 		case "time":
-			if !isValidTime(text) {
-				b.sendReplyMessage(userID, "Format waktu tidak valid.")
+			clock, ok := nlp.ParseTime(text)
+			if !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "time.invalid"))
+				return
+			}
+			if !fitsInDay(clock, schedule.Duration) {
+				b.sendReplyMessage(chatID, i18n.T(lang, "duration.past_midnight", esc(i18n.Duration(lang, time.Duration(schedule.Duration)*time.Minute))))
 				return
 			}
-			schedule.Time = text
+			schedule.Time = clock
 		case "days":
 			days := parsedays(text)
 			if len(days) == 0 {
-				b.sendReplyMessage(userID, "Format hari tidak valid.")
+				b.sendReplyMessage(chatID, i18n.T(lang, "days.invalid"))
 				return
 			}
 			schedule.Days = days
 		case "note":
-			if text == "-" {
+			if text == "-" || i18n.Match(text, "button.no_note") {
 				schedule.Note = ""
 			} else {
 				schedule.Note = text
 			}
+		case "tags":
+			if text == "-" || i18n.Match(text, "button.no_tags") {
+				schedule.Tags = nil
+				break
+			}
+			tags, err := storage.ParseTags(text)
+			if err != nil {
+				b.sendReplyMessage(chatID, renderError(lang, err))
+				return
+			}
+			schedule.Tags = tags
+		case "duration":
+			duration, ok := parseDurationInput(text, schedule.Time)
+			if !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "duration.invalid"))
+				return
+			}
+			schedule.Duration = duration
+		case "quiet":
+			mode, ok := parseQuietMode(text)
+			if !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+				return
+			}
+			schedule.QuietMode = mode
+			if mode == storage.QuietSilent {
+				schedule.QuietMode = ""
+			}
 		}
 
 		if err := b.storage.UpdateSchedule(schedule); err != nil {
-			b.sendMessage(userID, fmt.Sprintf("Error: %v", err))
-			delete(b.userState, userID)
+			b.sendMessage(chatID, renderError(lang, err))
+			delete(b.userState, k)
 		} else {
-			b.sendMessage(userID, "✅ "+field+" berhasil diperbarui!")
+			state.Data["schedule"] = schedule
+			if field == "time" || field == "days" {
+				b.Reschedule(schedule)
+			}
+			b.notifySubscribers(schedule)
+			b.sendMessage(chatID, i18n.T(lang, "field.updated", i18n.T(lang, "field."+field)))
+			if field == "time" || field == "days" || field == "duration" {
+				b.warnConflicts(chatID, schedule)
+			}
 			state.Action = "edit_continue"
-			b.userState[userID] = state
-			b.sendMessageWithKeyboard(userID, "Ingin melanjutkan edit field lain?", getEditContinueKeyboard())
+			b.userState[k] = state
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.edit_continue"), getEditContinueKeyboard(lang))
 		}
 
 	case "edit_continue":
-		if text == "✏️ Lanjut Edit" {
+		if i18n.Match(text, "button.edit_more") {
 			state.Action = "edit_field"
-			b.userState[userID] = state
-			b.sendMessageWithKeyboard(userID, "Pilih field yang ingin diubah:", getFieldKeyboard())
-		} else if text == "✅ Selesai" {
-			b.sendMessage(userID, "Perubahan jadwal selesai. Ketik /help untuk bantuan.")
-			delete(b.userState, userID)
+			b.userState[k] = state
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getFieldKeyboard(lang))
+		} else if i18n.Match(text, "button.done") {
+			b.sendMessage(chatID, i18n.T(lang, "edit.done"))
+			delete(b.userState, k)
 		} else {
-			b.sendReplyMessage(userID, "Pilihan tidak valid. Pilih dari tombol yang tersedia.")
-			b.sendMessageWithKeyboard(userID, "Ingin melanjutkan edit field lain?", getEditContinueKeyboard())
+			b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.edit_continue"), getEditContinueKeyboard(lang))
 		}
 
 	case "delete_id":
-		if err := b.storage.DeleteSchedule(text); err != nil {
-			b.sendMessage(userID, "Schedule tidak ditemukan.")
+		schedule, err := b.storage.GetSchedule(text)
+		if err == nil {
+			err = b.storage.DeleteSchedule(text)
+		}
+		if err != nil {
+			b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
 		} else {
-			b.sendMessage(userID, "✅ Jadwal berhasil dihapus!")
+			b.Remove(schedule)
+			b.sendMessage(chatID, i18n.T(lang, "schedule.deleted"))
 		}
-		delete(b.userState, userID)
+		delete(b.userState, k)
 
 	case "delete_title":
-		schedule, err := b.storage.GetScheduleByTitle(userID, text)
+		schedule, err := b.storage.GetScheduleByTitle(chatID, text)
 		if err != nil {
-			b.sendMessage(userID, "❌ Jadwal dengan judul tersebut tidak ditemukan.")
-			delete(b.userState, userID)
+			b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+			delete(b.userState, k)
 			return
 		}
 
 		if err := b.storage.DeleteSchedule(schedule.ID); err != nil {
-			b.sendMessage(userID, "Gagal menghapus jadwal.")
+			b.sendMessage(chatID, i18n.T(lang, "delete.failed"))
 		} else {
-			b.sendMessage(userID, "✅ Jadwal berhasil dihapus!")
+			b.Remove(schedule)
+			b.sendMessage(chatID, i18n.T(lang, "schedule.deleted"))
+		}
+		delete(b.userState, k)
+
+	case "bulk":
+		b.sendReplyMessage(chatID, i18n.T(lang, "bulk.use_buttons"))
+
+	case "bulk_value":
+		b.handleBulkValue(chatID, from, state, text)
+
+	case "duplicate_title":
+		b.saveDuplicate(chatID, from, state.Data["schedule"].(*storage.Schedule), text)
+
+	case "template_title":
+		b.saveTemplate(chatID, from, state.Data["schedule"].(*storage.Schedule), text)
+
+	case "draft_confirm", "draft_field", "draft_value":
+		b.handleDraft(chatID, from, state, text)
+
+	case "import_confirm":
+		if !i18n.Match(text, "button.save_all") {
+			b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "import.confirm"), getImportConfirmKeyboard(lang))
+			return
+		}
+		b.saveImport(chatID, from, state.Data["drafts"].([]*storage.Schedule))
+		delete(b.userState, k)
+
+	case "settings_value":
+		if b.setPreference(chatID, state.Data["field"].(string), text) {
+			delete(b.userState, k)
 		}
-		delete(b.userState, userID)
+
+	case "digest_time":
+		clock, ok := nlp.ParseTime(text)
+		if !ok {
+			b.sendReplyMessage(chatID, i18n.T(lang, "time.invalid"))
+			return
+		}
+		delete(b.userState, k)
+		b.setDigestTime(chatID, clock)
+
+	case "language_select":
+		delete(b.userState, k)
+		b.setLanguage(chatID, text)
 	}
 }
 
-func (b *Bot) listSchedules(userID int64) {
-	schedules := b.storage.GetUserSchedules(userID)
+// createSchedule stores the schedule collected by the /add wizard or a
+// confirmed free-text draft and registers its reminders.
+func (b *Bot) createSchedule(chatID int64, from *tgbotapi.User, data map[string]interface{}) {
+	// Create schedule with the chat's default reminder offsets
+	prefs := b.users.Preferences(chatID)
+	schedule := &storage.Schedule{
+		UserID:        chatID,
+		CreatedBy:     from.ID,
+		CreatorName:   displayName(from),
+		Title:         data["title"].(string),
+		Time:          data["time"].(string),
+		Days:          data["days"].([]string),
+		Note:          data["note"].(string),
+		ReminderType:  data["reminderType"].(string),
+		ReminderTimes: prefs.DefaultTimes(),
+		ReminderSent:  make(map[string]bool),
+	}
+	if tags, ok := data["tags"].([]string); ok {
+		schedule.Tags = tags
+	}
+	if duration, ok := data["duration"].(int); ok {
+		schedule.Duration = duration
+	}
 
-	if len(schedules) == 0 {
-		b.sendMessage(userID, "Anda belum memiliki jadwal. Gunakan /add untuk membuat jadwal baru.")
+	lang := b.lang(chatID)
+	if err := schedule.Validate(); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
 		return
 	}
+	if err := b.storage.AddSchedule(schedule); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	b.sendMessage(chatID, renderCreated(lang, schedule))
+	b.warnConflicts(chatID, schedule)
+	b.scheduleReminder(schedule)
+}
 
-	var text strings.Builder
-	text.WriteString("📅 Jadwal Anda:\n\n")
+// Reschedule replaces the cron jobs of schedule with ones built from its
+// current time, days and reminder offsets.
+func (b *Bot) Reschedule(schedule *storage.Schedule) {
+	b.Unschedule(schedule.ID)
+	b.scheduleReminder(schedule)
+}
 
-	for _, s := range schedules {
-		text.WriteString(fmt.Sprintf("📌 Judul: %s\n", s.Title))
-		text.WriteString(fmt.Sprintf("⏰ Waktu: %s\n", s.Time))
-		text.WriteString(fmt.Sprintf("📆 Hari: %s\n", strings.Join(s.Days, ", ")))
-		if s.Note != "" {
-			text.WriteString(fmt.Sprintf("📝 Catatan: %s\n", s.Note))
-		}
-		text.WriteString("\n")
+// Unschedule removes every cron job registered for the schedule id.
+func (b *Bot) Unschedule(id string) {
+	b.jobsMu.Lock()
+	entries := b.jobs[id]
+	delete(b.jobs, id)
+	b.jobsMu.Unlock()
+
+	for _, entry := range entries {
+		b.cron.Remove(entry)
 	}
+}
 
-	b.sendMessageHTML(userID, text.String())
+// Remove drops the cron jobs of a deleted schedule and tells its
+// subscribers, muted or not, that it is gone.
+func (b *Bot) Remove(schedule *storage.Schedule) {
+	b.Unschedule(schedule.ID)
+	for _, chatID := range schedule.Subscribers {
+		b.sendMessage(chatID, i18n.T(b.lang(chatID), "share.deleted", bold(schedule.Title)))
+	}
 }
 
+// finishOnce deletes a "once" schedule after its main notification and all
+// reminders have been sent and its nag, if any, has ended.
+func (b *Bot) finishOnce(schedule *storage.Schedule) {
+	if schedule.ReminderType != storage.ReminderOnce || schedule.PendingNag != nil {
+		return
+	}
+	totalNotifications := 1 + len(schedule.ReminderTimes) // 1 main + reminders
+	if len(schedule.ReminderSent) == totalNotifications {
+		b.storage.DeleteSchedule(schedule.ID)
+		b.Unschedule(schedule.ID)
+	}
+}
+
+// scheduleReminder registers the cron jobs of schedule, replacing the ones
+// registered for it before so a schedule never fires twice.
 func (b *Bot) scheduleReminder(schedule *storage.Schedule) {
+	scheduleHour, scheduleMin, err := storage.ParseClock(schedule.Time)
+	if err != nil {
+		log.Printf("Jadwal %s dilewati: %v\n", schedule.ID, err)
+		return
+	}
+
+	var entries []cron.EntryID
 	for _, day := range schedule.Days {
-		// Parse hour and minute from time string
-		var scheduleHour, scheduleMin int
-		fmt.Sscanf(schedule.Time, "%d:%d", &scheduleHour, &scheduleMin)
-		
 		// 1. Schedule MAIN notification (pada waktu yang sebenarnya)
 		weekday := dayToCronDay(day)
-		mainCronExpression := fmt.Sprintf("%d %d * * %s", scheduleMin, scheduleHour, weekday)
+		mainCronExpression := fmt.Sprintf("%d %d * * %d", scheduleMin, scheduleHour, weekday)
 		scheduleID := schedule.ID
 		mainNotifKey := fmt.Sprintf("%s_main", scheduleID)
-		
-		_, err := b.cron.AddFunc(mainCronExpression, func() {
+
+		entry, err := b.cron.AddFunc(b.cronSpec(schedule.UserID, mainCronExpression), func() {
 			// Refresh schedule dari storage untuk get latest data
 			latestSchedule, err := b.storage.GetSchedule(scheduleID)
 			if err != nil {
 				return
 			}
-			
-			note := latestSchedule.Note
-			if note == "" {
-				note = "(tanpa catatan)"
+
+			// Paused, skipped or holiday occurrences neither send nor
+			// mark anything as sent
+			at := time.Now().In(b.loc(latestSchedule.UserID))
+			if !b.firesOn(latestSchedule, at) {
+				return
 			}
-			
+
 			// Check if main notification already sent (for "once" type)
 			if latestSchedule.ReminderType == "once" {
 				if latestSchedule.ReminderSent[mainNotifKey] {
 					return // Notifikasi utama sudah pernah dikirim
 				}
 			}
-			
-			// Send MAIN notification
-			mainText := fmt.Sprintf("🔔 WAKTUNYA SEKARANG!\n📌 %s\n⏰ Waktu: %s\n📝 %s", 
-				latestSchedule.Title, 
-				latestSchedule.Time,
-				note)
-			b.sendMessage(latestSchedule.UserID, mainText)
-			
+
+			// Send MAIN notification to the owner and subscribers, asking
+			// for attendance when the schedule is shared; a priority
+			// schedule keeps repeating it until someone taps Selesai
+			date := at.Format(storage.DateLayout)
+			b.notify(latestSchedule, func(lang i18n.Lang) string {
+				return renderMainNotification(lang, latestSchedule)
+			}, doneButtons(latestSchedule, date, rsvpButtons(latestSchedule, date)))
+			b.startNag(latestSchedule, date)
+
+			// Recurring schedules count the occurrence for /stats
+			if latestSchedule.ReminderType == storage.ReminderRecurring {
+				if err := b.storage.MarkFired(scheduleID, date); err != nil {
+					log.Printf("Gagal mencatat kejadian %s: %v\n", scheduleID, err)
+				}
+			}
+
 			// Mark as sent if type is "once"
 			if latestSchedule.ReminderType == "once" {
 				if latestSchedule.ReminderSent == nil {
@@ -466,63 +885,63 @@ func (b *Bot) scheduleReminder(schedule *storage.Schedule) {
 				}
 				latestSchedule.ReminderSent[mainNotifKey] = true
 				b.storage.UpdateSchedule(latestSchedule)
-				
+
 				// If all notifications sent (main + all reminders), delete the schedule
-				totalNotifications := 1 + len(latestSchedule.ReminderTimes) // 1 main + reminders
-				if len(latestSchedule.ReminderSent) == totalNotifications {
-					b.storage.DeleteSchedule(scheduleID)
-				}
+				b.finishOnce(latestSchedule)
 			}
 		})
-		
+
 		if err != nil {
 			log.Printf("Error scheduling main notification: %v\n", err)
+		} else {
+			entries = append(entries, entry)
 		}
-		
+
 		// 2. Schedule REMINDER notifications (sebelum waktu utama)
 		for _, reminderMinutes := range schedule.ReminderTimes {
-			// Calculate reminder time
-			reminderHour := scheduleHour
-			reminderMin := scheduleMin - reminderMinutes
-			
-			if reminderMin < 0 {
-				reminderMin += 60
-				reminderHour--
-				if reminderHour < 0 {
-					reminderHour = 23
-				}
+			// Calculate reminder time, moving to earlier days when the
+			// offset crosses midnight
+			reminderDay := weekday
+			offset := scheduleHour*60 + scheduleMin - reminderMinutes
+			for offset < 0 {
+				offset += 24 * 60
+				reminderDay = (reminderDay + 6) % 7
 			}
-			
-			cronExpression := fmt.Sprintf("%d %d * * %s", reminderMin, reminderHour, weekday)
+			reminderHour, reminderMin := offset/60, offset%60
+
+			cronExpression := fmt.Sprintf("%d %d * * %d", reminderMin, reminderHour, reminderDay)
 			reminderKey := fmt.Sprintf("%s_%dm", scheduleID, reminderMinutes)
-			
-			_, err := b.cron.AddFunc(cronExpression, func() {
+
+			entry, err := b.cron.AddFunc(b.cronSpec(schedule.UserID, cronExpression), func() {
 				// Refresh schedule dari storage untuk get latest data
 				latestSchedule, err := b.storage.GetSchedule(scheduleID)
 				if err != nil {
 					return
 				}
-				
-				note := latestSchedule.Note
-				if note == "" {
-					note = "(tanpa catatan)"
+
+				// A reminder belongs to the occurrence it announces,
+				// which may fall on the next day
+				at := time.Now().In(b.loc(latestSchedule.UserID)).Add(time.Duration(reminderMinutes) * time.Minute)
+				if !b.firesOn(latestSchedule, at) {
+					return
 				}
-				
+
 				// Check if reminder already sent (for "once" type)
 				if latestSchedule.ReminderType == "once" {
 					if latestSchedule.ReminderSent[reminderKey] {
 						return // Reminder sudah pernah dikirim
 					}
 				}
-				
-				// Send reminder
-				reminderText := fmt.Sprintf("⏰ Pengingat %d menit sebelum:\n📌 %s\n📝 %s\n⏰ Waktu: %s", 
-					reminderMinutes, 
-					latestSchedule.Title, 
-					note,
-					latestSchedule.Time)
-				b.sendMessage(latestSchedule.UserID, reminderText)
-				
+
+				// Send reminder to the owner and subscribers, minding their
+				// quiet hours; the first one asks for attendance ahead of a
+				// shared event
+				var buttons func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup
+				if reminderMinutes == earliestReminder(latestSchedule.ReminderTimes) {
+					buttons = rsvpButtons(latestSchedule, at.Format(storage.DateLayout))
+				}
+				b.remind(latestSchedule, reminderMinutes, at.Truncate(time.Minute), buttons)
+
 				// Mark as sent if type is "once"
 				if latestSchedule.ReminderType == "once" {
 					if latestSchedule.ReminderSent == nil {
@@ -530,78 +949,86 @@ func (b *Bot) scheduleReminder(schedule *storage.Schedule) {
 					}
 					latestSchedule.ReminderSent[reminderKey] = true
 					b.storage.UpdateSchedule(latestSchedule)
-					
+
 					// Check if all notifications sent
-					totalNotifications := 1 + len(latestSchedule.ReminderTimes)
-					if len(latestSchedule.ReminderSent) == totalNotifications {
-						b.storage.DeleteSchedule(scheduleID)
-					}
+					b.finishOnce(latestSchedule)
 				}
 			})
-			
+
 			if err != nil {
 				log.Printf("Error scheduling reminder: %v\n", err)
+			} else {
+				entries = append(entries, entry)
 			}
 		}
 	}
-}
 
-func (b *Bot) sendMessage(userID int64, text string) {
-	msg := tgbotapi.NewMessage(userID, text)
-	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
-	b.api.Send(msg)
-}
+	b.jobsMu.Lock()
+	previous := b.jobs[schedule.ID]
+	b.jobs[schedule.ID] = entries
+	b.jobsMu.Unlock()
 
-func (b *Bot) sendReplyMessage(userID int64, text string) {
-	msg := tgbotapi.NewMessage(userID, text)
-	b.api.Send(msg)
+	for _, entry := range previous {
+		b.cron.Remove(entry)
+	}
 }
 
-func (b *Bot) sendMessageHTML(userID int64, text string) {
-	msg := tgbotapi.NewMessage(userID, text)
-	msg.ParseMode = "HTML"
+// The send helpers below all use HTML parse mode; see render.go for how
+// user content is escaped.
+
+func (b *Bot) sendMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
 	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
-	b.api.Send(msg)
+	b.send(msg)
 }
 
-func (b *Bot) sendMessageWithKeyboard(userID int64, text string, keyboard tgbotapi.ReplyKeyboardMarkup) {
-	msg := tgbotapi.NewMessage(userID, text)
-	msg.ReplyMarkup = keyboard
-	b.api.Send(msg)
+func (b *Bot) sendReplyMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	b.send(msg)
 }
 
-func (b *Bot) Stop() {
-	b.cron.Stop()
+// sendMessageWithKeyboard sends text with a reply or inline keyboard.
+func (b *Bot) sendMessageWithKeyboard(chatID int64, text string, keyboard interface{}) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = keyboard
+	b.send(msg)
 }
 
-// Helper functions
-
-func getHelpText() string {
-	return `🤖 Schedule Bot - Bantuan
-
-Perintah yang tersedia:
-/add - Tambah jadwal baru
-/list - Lihat semua jadwal
-/edit - Ubah jadwal
-/delete - Hapus jadwal
-/help - Tampilkan bantuan ini
-
-Contoh penggunaan:
-1. Ketik /add
-2. Ikuti petunjuk untuk membuat jadwal baru
-3. Bot akan mengingatkan Anda sesuai jadwal
+// editMessage replaces the text of a message sent by the bot, together with
+// its inline keyboard when one is given.
+func (b *Bot) editMessage(chatID int64, messageID int, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.ReplyMarkup = keyboard
+	b.send(edit)
+}
 
-Untuk pertanyaan, silakan hubungi @FtrRahman`
+// editMessageKeyboard replaces only the inline keyboard of a message; nil
+// removes it.
+func (b *Bot) editMessageKeyboard(chatID int64, messageID int, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	if keyboard == nil {
+		keyboard = &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+	}
+	b.send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, *keyboard))
 }
 
-func isValidTime(t string) bool {
-	parts := strings.Split(t, ":")
-	if len(parts) != 2 {
-		return false
+// send logs messages Telegram refuses, such as malformed HTML, instead of
+// dropping them silently.
+func (b *Bot) send(c tgbotapi.Chattable) {
+	if _, err := b.api.Send(c); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("Gagal mengirim pesan: %v\n", err)
 	}
-	return len(parts[0]) == 2 && len(parts[1]) == 2
 }
 
+func (b *Bot) Stop() {
+	b.cron.Stop()
+}
+
+// Helper functions
+
 func parsedays(text string) []string {
 	days := strings.Split(text, ",")
 	validDays := map[string]bool{
@@ -611,19 +1038,19 @@ func parsedays(text string) []string {
 
 	// Map keyboard button format ke format valid
 	dayButtonMap := map[string]string{
-		"Senin (Monday)":       "Monday",
-		"Selasa (Tuesday)":     "Tuesday",
-		"Rabu (Wednesday)":     "Wednesday",
-		"Kamis (Thursday)":     "Thursday",
-		"Jumat (Friday)":       "Friday",
-		"Sabtu (Saturday)":     "Saturday",
-		"Minggu (Sunday)":      "Sunday",
+		"Senin (Monday)":   "Monday",
+		"Selasa (Tuesday)": "Tuesday",
+		"Rabu (Wednesday)": "Wednesday",
+		"Kamis (Thursday)": "Thursday",
+		"Jumat (Friday)":   "Friday",
+		"Sabtu (Saturday)": "Saturday",
+		"Minggu (Sunday)":  "Sunday",
 	}
 
 	var result []string
 	for _, day := range days {
 		day = strings.TrimSpace(day)
-		
+
 		// Try direct match first
 		if validDays[day] {
 			result = append(result, day)
@@ -635,6 +1062,11 @@ func parsedays(text string) []string {
 			result = append(result, mappedDay)
 			continue
 		}
+
+		// Try day names of every supported language
+		if mappedDay, ok := i18n.ParseDay(day); ok {
+			result = append(result, mappedDay)
+		}
 	}
 	return result
 }
@@ -654,7 +1086,7 @@ func convertTimeToMinHour(timeStr string) string {
 	return fmt.Sprintf("%s %s", parts[1], parts[0])
 }
 
-func dayToCronDay(day string) string {
+func dayToCronDay(day string) int {
 	dayMap := map[string]int{
 		"Sunday":    0,
 		"Monday":    1,
@@ -664,127 +1096,93 @@ func dayToCronDay(day string) string {
 		"Friday":    5,
 		"Saturday":  6,
 	}
-	return fmt.Sprintf("%d", dayMap[day])
+	return dayMap[day]
 }
 
 // Keyboard helper functions
 
-func getTimeKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getDaysKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	day := func(name string) tgbotapi.KeyboardButton {
+		return tgbotapi.NewKeyboardButton(i18n.DayButton(lang, name))
+	}
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("00:00"),
-			tgbotapi.NewKeyboardButton("01:00"),
-			tgbotapi.NewKeyboardButton("02:00"),
-			tgbotapi.NewKeyboardButton("03:00"),
-			tgbotapi.NewKeyboardButton("04:00"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("05:00"),
-			tgbotapi.NewKeyboardButton("06:00"),
-			tgbotapi.NewKeyboardButton("07:00"),
-			tgbotapi.NewKeyboardButton("08:00"),
-			tgbotapi.NewKeyboardButton("09:00"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("10:00"),
-			tgbotapi.NewKeyboardButton("11:00"),
-			tgbotapi.NewKeyboardButton("12:00"),
-			tgbotapi.NewKeyboardButton("13:00"),
-			tgbotapi.NewKeyboardButton("14:00"),
+			day("Monday"),
+			day("Tuesday"),
+			day("Wednesday"),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("15:00"),
-			tgbotapi.NewKeyboardButton("16:00"),
-			tgbotapi.NewKeyboardButton("17:00"),
-			tgbotapi.NewKeyboardButton("18:00"),
-			tgbotapi.NewKeyboardButton("19:00"),
+			day("Thursday"),
+			day("Friday"),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("20:00"),
-			tgbotapi.NewKeyboardButton("21:00"),
-			tgbotapi.NewKeyboardButton("22:00"),
-			tgbotapi.NewKeyboardButton("23:00"),
+			day("Saturday"),
+			day("Sunday"),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.days_done")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
 		),
 	)
 }
 
-func getDaysKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getNoteKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Senin (Monday)"),
-			tgbotapi.NewKeyboardButton("Selasa (Tuesday)"),
-			tgbotapi.NewKeyboardButton("Rabu (Wednesday)"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Kamis (Thursday)"),
-			tgbotapi.NewKeyboardButton("Jumat (Friday)"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Sabtu (Saturday)"),
-			tgbotapi.NewKeyboardButton("Minggu (Sunday)"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.no_note")),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("🔄 Selesai Pilih"),
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
 		),
 	)
 }
 
-func getNoteKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getSkipKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Tidak ada catatan"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
 		),
 	)
 }
 
-func getSkipKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getFieldKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.title")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.time")),
 		),
-	)
-}
-
-func getFieldKeyboard() tgbotapi.ReplyKeyboardMarkup {
-	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("1️⃣ Title"),
-			tgbotapi.NewKeyboardButton("2️⃣ Waktu"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.days")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.note")),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("3️⃣ Hari"),
-			tgbotapi.NewKeyboardButton("4️⃣ Catatan"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.tags")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.duration")),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.quiet")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
 		),
 	)
 }
 
-func getReminderTypeKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getReminderTypeKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("🔔 Sekali"),
-			tgbotapi.NewKeyboardButton("🔊 Berkali-kali"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.once")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.recurring")),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Batal"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
 		),
 	)
 }
 
-func getEditContinueKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func getEditContinueKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("✏️ Lanjut Edit"),
-			tgbotapi.NewKeyboardButton("✅ Selesai"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.edit_more")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.done")),
 		),
 	)
 }
@@ -0,0 +1,485 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
+	"turschedule/internal/storage"
+)
+
+// bulkPageSize is how many schedules one page of the /bulk checklist shows.
+const bulkPageSize = 8
+
+// bulkShifts are the offered time shifts in minutes.
+var bulkShifts = []int{-60, -30, -15, 15, 30, 60}
+
+// bulkUndo remembers the schedules of a chat as they were before its last
+// bulk action. It is kept in memory only, so a restart drops it.
+type bulkUndo struct {
+	token     string
+	schedules []*storage.Schedule
+}
+
+// startBulk handles /bulk [#tag]: a checklist over the chat's schedules,
+// optionally only those with tag, to change many of them at once. The
+// selection lives in the conversation state of the sender.
+func (b *Bot) startBulk(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+	tag, _, err := splitTagFilter(args)
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	if len(filterByTag(b.storage.GetUserSchedules(chatID), tag)) == 0 {
+		text := i18n.T(lang, "schedules.none")
+		if tag != "" {
+			text = i18n.T(lang, "tag.none", esc(tag))
+		}
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	state := UserState{
+		Action: "bulk",
+		Data:   map[string]interface{}{"selected": make(map[string]bool), "page": 0, "tag": tag},
+	}
+	b.userState[stateKey{chatID, from.ID}] = state
+	text, keyboard := b.renderBulk(chatID, state)
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// bulkSchedules returns the schedules the checklist of state offers, in
+// the order of /list.
+func (b *Bot) bulkSchedules(chatID int64, state UserState) []*storage.Schedule {
+	schedules := filterByTag(b.storage.GetUserSchedules(chatID), state.Data["tag"].(string))
+	sortSchedules(schedules, listSortNext, time.Now().In(b.loc(chatID)))
+	return schedules
+}
+
+// bulkSelection returns the IDs of the selected schedules that still exist,
+// sorted.
+func (b *Bot) bulkSelection(chatID int64, state UserState) []string {
+	var ids []string
+	for id := range state.Data["selected"].(map[string]bool) {
+		if schedule, err := b.storage.GetSchedule(id); err == nil && schedule.UserID == chatID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// renderBulk renders the checklist page of state with the action buttons.
+func (b *Bot) renderBulk(chatID int64, state UserState) (string, tgbotapi.InlineKeyboardMarkup) {
+	lang := b.lang(chatID)
+	schedules := b.bulkSchedules(chatID, state)
+	selected := state.Data["selected"].(map[string]bool)
+
+	pages := (len(schedules) + bulkPageSize - 1) / bulkPageSize
+	page := state.Data["page"].(int)
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	start := page * bulkPageSize
+	end := start + bulkPageSize
+	if end > len(schedules) {
+		end = len(schedules)
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, s := range schedules[start:end] {
+		mark := "⬜"
+		if selected[s.ID] {
+			mark = "☑️"
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%s %s • %s", mark, s.Title, s.Time), "blk:t:"+s.ID),
+		))
+	}
+	if pages > 1 {
+		var nav []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("blk:pg:%d", page-1)))
+		}
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, pages), fmt.Sprintf("blk:pg:%d", page)))
+		if page < pages-1 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("blk:pg:%d", page+1)))
+		}
+		rows = append(rows, nav)
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.all"), "blk:all"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.none"), "blk:none"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.del"), "blk:a:del"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.pause"), "blk:a:pause"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.resume"), "blk:a:resume"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.tag"), "blk:a:tag"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.shift"), "blk:a:shift"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.type"), "blk:a:type"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.cancel"), "blk:cancel"),
+		),
+	)
+
+	count := 0
+	for _, s := range schedules {
+		if selected[s.ID] {
+			count++
+		}
+	}
+	text := i18n.T(lang, "bulk.header", count, len(schedules))
+	if tag := state.Data["tag"].(string); tag != "" {
+		text += "\n" + i18n.T(lang, "tag.filter", esc(tag))
+	}
+	return text, tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleBulkCallback handles the /bulk buttons, sent as "blk:<verb>[:<arg>]".
+// Apart from undo they only work for the member who opened the checklist.
+func (b *Bot) handleBulkCallback(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	lang := b.lang(chatID)
+	verb, arg, _ := strings.Cut(value, ":")
+	if verb == "undo" {
+		b.undoBulk(chatID, from, messageID, arg)
+		return
+	}
+
+	k := stateKey{chatID, from.ID}
+	state, exists := b.userState[k]
+	if !exists || (state.Action != "bulk" && state.Action != "bulk_value") {
+		b.editMessage(chatID, messageID, i18n.T(lang, "bulk.expired"), nil)
+		return
+	}
+	state.Data["message"] = messageID
+
+	switch verb {
+	case "t":
+		selected := state.Data["selected"].(map[string]bool)
+		if selected[arg] {
+			delete(selected, arg)
+		} else {
+			selected[arg] = true
+		}
+	case "pg":
+		page, _ := strconv.Atoi(arg)
+		state.Data["page"] = page
+	case "all", "none":
+		selected := make(map[string]bool)
+		if verb == "all" {
+			for _, s := range b.bulkSchedules(chatID, state) {
+				selected[s.ID] = true
+			}
+		}
+		state.Data["selected"] = selected
+	case "back":
+	case "cancel":
+		delete(b.userState, k)
+		b.editMessage(chatID, messageID, i18n.T(lang, "cancelled"), nil)
+		return
+	case "a":
+		b.askBulk(chatID, k, state, messageID, arg)
+		return
+	case "ok":
+		action, option, _ := strings.Cut(arg, ":")
+		b.applyBulk(chatID, k, state, action, option)
+		return
+	}
+
+	state.Action = "bulk"
+	b.userState[k] = state
+	text, keyboard := b.renderBulk(chatID, state)
+	b.editMessage(chatID, messageID, text, &keyboard)
+}
+
+// askBulk starts action on the selection: pausing and resuming apply right
+// away, the others ask for confirmation or a value first.
+func (b *Bot) askBulk(chatID int64, k stateKey, state UserState, messageID int, action string) {
+	lang := b.lang(chatID)
+	ids := b.bulkSelection(chatID, state)
+	if len(ids) == 0 {
+		b.sendReplyMessage(chatID, i18n.T(lang, "bulk.none_selected"))
+		return
+	}
+	back := tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.back"), "blk:back")
+	n := len(ids)
+
+	var (
+		text string
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+	switch action {
+	case "pause", "resume":
+		b.applyBulk(chatID, k, state, action, "")
+		return
+	case "del":
+		var schedules []*storage.Schedule
+		for _, id := range ids {
+			if schedule, err := b.storage.GetSchedule(id); err == nil {
+				schedules = append(schedules, schedule)
+			}
+		}
+		text = i18n.N(lang, "bulk.delete_confirm", n, n) + renderTitles(schedules)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.delete_yes"), "blk:ok:del"),
+			back,
+		))
+	case "type":
+		text = i18n.N(lang, "bulk.ask_type", n, n)
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.once"), "blk:ok:type:"+storage.ReminderOnce),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.recurring"), "blk:ok:type:"+storage.ReminderRecurring),
+			),
+			tgbotapi.NewInlineKeyboardRow(back),
+		)
+	case "tag":
+		text = i18n.N(lang, "bulk.ask_tag", n, n)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.notag"), "blk:ok:tag:-"),
+			back,
+		))
+	case "shift":
+		text = i18n.N(lang, "bulk.ask_shift", n, n)
+		var row []tgbotapi.InlineKeyboardButton
+		for _, minutes := range bulkShifts {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%+d", minutes), fmt.Sprintf("blk:ok:shift:%+d", minutes)))
+		}
+		rows = append(rows, row, tgbotapi.NewInlineKeyboardRow(back))
+	default:
+		return
+	}
+
+	// Tags and shifts may also be typed, see handleBulkValue
+	state.Action = "bulk"
+	if action == "tag" || action == "shift" {
+		state.Action = "bulk_value"
+		state.Data["field"] = action
+	}
+	b.userState[k] = state
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, text, &keyboard)
+}
+
+// handleBulkValue takes a typed tag list or time shift for the selection.
+func (b *Bot) handleBulkValue(chatID int64, from *tgbotapi.User, state UserState, text string) {
+	b.applyBulk(chatID, stateKey{chatID, from.ID}, state, state.Data["field"].(string), text)
+}
+
+// applyBulk performs action on every selected schedule in one storage
+// transaction, then replaces the checklist with a summary that can be
+// undone. When one schedule cannot take the change nothing is changed and
+// the selection stays open.
+func (b *Bot) applyBulk(chatID int64, k stateKey, state UserState, action, option string) {
+	lang := b.lang(chatID)
+	ids := b.bulkSelection(chatID, state)
+	if len(ids) == 0 {
+		b.sendReplyMessage(chatID, i18n.T(lang, "bulk.none_selected"))
+		return
+	}
+
+	var (
+		change func(*storage.Schedule) error
+		detail string
+	)
+	switch action {
+	case "pause":
+		change = func(s *storage.Schedule) error {
+			s.Pause(time.Time{})
+			return nil
+		}
+	case "resume":
+		change = func(s *storage.Schedule) error {
+			s.Resume()
+			return nil
+		}
+	case "type":
+		if option != storage.ReminderOnce && option != storage.ReminderRecurring {
+			return
+		}
+		detail = renderType(lang, option)
+		change = func(s *storage.Schedule) error {
+			s.ReminderType = option
+			return nil
+		}
+	case "tag":
+		var tags []string
+		if option != "-" {
+			var err error
+			if tags, err = storage.ParseTags(option); err != nil || len(tags) == 0 {
+				b.sendReplyMessage(chatID, i18n.T(lang, "bulk.tag_invalid"))
+				return
+			}
+		}
+		detail = i18n.T(lang, "bulk.no_tags")
+		if len(tags) > 0 {
+			detail = esc(renderTags(tags))
+		}
+		change = func(s *storage.Schedule) error {
+			s.Tags = append([]string(nil), tags...)
+			return nil
+		}
+	case "shift":
+		delta, ok := parseShift(option)
+		if !ok {
+			b.sendReplyMessage(chatID, i18n.T(lang, "bulk.shift_invalid"))
+			return
+		}
+		detail = renderShift(lang, delta)
+		change = func(s *storage.Schedule) error {
+			hour, minute, err := storage.ParseClock(s.Time)
+			if err != nil {
+				return err
+			}
+			start := hour*60 + minute + delta
+			if start < 0 || start >= storage.MinutesPerDay {
+				return fmt.Errorf("waktu %s digeser %d menit melewati hari yang sama", s.Time, delta)
+			}
+			s.Time = storage.FormatClock(start)
+			return nil
+		}
+	}
+
+	var (
+		before []*storage.Schedule
+		err    error
+	)
+	if action == "del" {
+		before, err = b.storage.BulkDelete(ids)
+	} else if change != nil {
+		before, err = b.storage.BulkUpdate(ids, change)
+	} else {
+		return
+	}
+	if err != nil {
+		log.Printf("Gagal menjalankan aksi massal %s untuk %d: %v\n", action, chatID, err)
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	for _, s := range before {
+		if action == "del" {
+			b.Remove(s)
+			continue
+		}
+		if schedule, err := b.storage.GetSchedule(s.ID); err == nil {
+			b.Reschedule(schedule)
+			b.notifySubscribers(schedule)
+		}
+	}
+
+	delete(b.userState, k)
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	b.undo[chatID] = bulkUndo{token: token, schedules: before}
+
+	n := len(ids)
+	text := i18n.N(lang, "bulk.done."+action, n, n)
+	if detail != "" {
+		text = i18n.N(lang, "bulk.done."+action, n, n, detail)
+	}
+	var titles strings.Builder
+	for _, s := range before {
+		titles.WriteString("\n• " + bold(s.Title))
+		if action == "shift" {
+			if after, err := b.storage.GetSchedule(s.ID); err == nil {
+				titles.WriteString(" " + code(s.Time) + " → " + code(after.Time))
+			}
+		}
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "bulk.button.undo"), "blk:undo:"+token),
+	))
+
+	messageID, _ := state.Data["message"].(int)
+	if messageID == 0 {
+		b.sendMessageWithKeyboard(chatID, text+titles.String(), keyboard)
+		return
+	}
+	b.editMessage(chatID, messageID, text+titles.String(), &keyboard)
+}
+
+// undoBulk restores the schedules changed by the last bulk action of
+// chatID, if token still names it.
+func (b *Bot) undoBulk(chatID int64, from *tgbotapi.User, messageID int, token string) {
+	lang := b.lang(chatID)
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+	undo, exists := b.undo[chatID]
+	if !exists || undo.token != token {
+		b.editMessageKeyboard(chatID, messageID, nil)
+		b.sendReplyMessage(chatID, i18n.T(lang, "bulk.undo_expired"))
+		return
+	}
+
+	if err := b.storage.Restore(undo.schedules); err != nil {
+		log.Printf("Gagal mengurungkan aksi massal untuk %d: %v\n", chatID, err)
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+	delete(b.undo, chatID)
+	for _, s := range undo.schedules {
+		if schedule, err := b.storage.GetSchedule(s.ID); err == nil {
+			b.Reschedule(schedule)
+			b.notifySubscribers(schedule)
+		}
+	}
+
+	n := len(undo.schedules)
+	b.editMessage(chatID, messageID, i18n.N(lang, "bulk.undone", n, n)+renderTitles(undo.schedules), nil)
+}
+
+// renderTitles lists the titles of schedules, one per line.
+func renderTitles(schedules []*storage.Schedule) string {
+	var text strings.Builder
+	for _, s := range schedules {
+		text.WriteString("\n• " + bold(s.Title))
+	}
+	return text.String()
+}
+
+// parseShift reads a time shift such as "+30", "-15" or "+1 jam" in
+// minutes. It must move by less than a day.
+func parseShift(text string) (int, bool) {
+	text = strings.TrimSpace(text)
+	sign := 1
+	switch {
+	case strings.HasPrefix(text, "-"), strings.HasPrefix(text, "−"):
+		sign = -1
+		text = strings.TrimLeft(text, "-−")
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+	minutes, ok := nlp.ParseDuration(text, "00:00")
+	if !ok || minutes >= storage.MinutesPerDay {
+		return 0, false
+	}
+	return sign * minutes, true
+}
+
+// renderShift renders a time shift as "+30 menit" or "-1 jam".
+func renderShift(lang i18n.Lang, minutes int) string {
+	sign := "+"
+	if minutes < 0 {
+		sign, minutes = "-", -minutes
+	}
+	return sign + esc(i18n.Duration(lang, time.Duration(minutes)*time.Minute))
+}
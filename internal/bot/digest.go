@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/robfig/cron/v3"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// defaultDigestTime is used when the daily digest is turned on before a time
+// was chosen.
+const defaultDigestTime = "07:00"
+
+// weeklyDigestSpec sends the weekly overview on Sunday evening.
+const weeklyDigestSpec = "0 19 * * 0"
+
+// digestJobKey names the cron entries of chatID's digests in b.jobs, next to
+// the schedule IDs.
+func digestJobKey(chatID int64) string {
+	return fmt.Sprintf("digest_%d", chatID)
+}
+
+// scheduleDigest replaces the digest cron jobs of chatID with ones built
+// from its current settings.
+func (b *Bot) scheduleDigest(chatID int64) {
+	key := digestJobKey(chatID)
+	b.Unschedule(key)
+
+	daily, weekly := b.users.Digest(chatID)
+	var entries []cron.EntryID
+	if daily != "" {
+		hour, minute, err := storage.ParseClock(daily)
+		if err != nil {
+			log.Printf("Digest %d dilewati: %v\n", chatID, err)
+		} else if entry, err := b.cron.AddFunc(b.cronSpec(chatID, fmt.Sprintf("%d %d * * *", minute, hour)), func() {
+			b.sendDailyDigest(chatID)
+		}); err != nil {
+			log.Printf("Error scheduling daily digest: %v\n", err)
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+	if weekly {
+		if entry, err := b.cron.AddFunc(b.cronSpec(chatID, weeklyDigestSpec), func() {
+			b.sendWeeklyDigest(chatID)
+		}); err != nil {
+			log.Printf("Error scheduling weekly digest: %v\n", err)
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) > 0 {
+		b.jobsMu.Lock()
+		b.jobs[key] = entries
+		b.jobsMu.Unlock()
+	}
+}
+
+// sendDailyDigest sends today's occurrences in one message. Days without
+// any are left quiet.
+func (b *Bot) sendDailyDigest(chatID int64) {
+	lang := b.lang(chatID)
+	now := time.Now().In(b.loc(chatID))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	occurrences := b.occurrencesBetween(chatID, today, today.AddDate(0, 0, 1), "")
+	if len(occurrences) == 0 {
+		return
+	}
+	header := i18n.N(lang, "digest.daily", len(occurrences), esc(i18n.FormatWeekdayDate(lang, today)), len(occurrences))
+	b.sendMessage(chatID, header+renderOccurrences(lang, occurrences, now, false))
+}
+
+// sendWeeklyDigest sends the occurrences of the coming Monday to Sunday,
+// grouped by day.
+func (b *Bot) sendWeeklyDigest(chatID int64) {
+	lang := b.lang(chatID)
+	now := time.Now().In(b.loc(chatID))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday := today.AddDate(0, 0, (8-int(today.Weekday()))%7)
+	if monday.Equal(today) {
+		monday = today.AddDate(0, 0, 7)
+	}
+
+	occurrences := b.occurrencesBetween(chatID, monday, monday.AddDate(0, 0, 7), "")
+	if len(occurrences) == 0 {
+		return
+	}
+	header := i18n.N(lang, "digest.weekly", len(occurrences), esc(i18n.FormatDate(lang, monday)), len(occurrences))
+	b.sendMessage(chatID, header+renderOccurrences(lang, occurrences, now, true))
+}
+
+// sendDigestSettings shows the /digest menu, editing messageID when it is
+// not zero.
+func (b *Bot) sendDigestSettings(chatID int64, messageID int) {
+	lang := b.lang(chatID)
+	daily, weekly := b.users.Digest(chatID)
+	dailyStatus, weeklyStatus := digestStatus(lang, daily, weekly)
+	text := i18n.T(lang, "digest.settings", dailyStatus, weeklyStatus)
+
+	keyboard := getDigestKeyboard(lang, daily != "", weekly)
+	if messageID != 0 {
+		b.editMessage(chatID, messageID, text, &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = keyboard
+	b.send(msg)
+}
+
+// digestStatus describes the daily and weekly digest settings.
+func digestStatus(lang i18n.Lang, daily string, weekly bool) (string, string) {
+	dailyStatus := i18n.T(lang, "digest.off")
+	if daily != "" {
+		dailyStatus = i18n.T(lang, "digest.on_at", code(daily))
+	}
+	weeklyStatus := i18n.T(lang, "digest.off")
+	if weekly {
+		weeklyStatus = i18n.T(lang, "digest.on")
+	}
+	return dailyStatus, weeklyStatus
+}
+
+// handleDigestCallback handles the buttons of the /digest menu.
+func (b *Bot) handleDigestCallback(chatID int64, from *tgbotapi.User, messageID int, action string) {
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+	lang := b.lang(chatID)
+	daily, weekly := b.users.Digest(chatID)
+
+	switch action {
+	case "daily":
+		if daily == "" {
+			daily = defaultDigestTime
+		} else {
+			daily = ""
+		}
+	case "weekly":
+		weekly = !weekly
+	case "time":
+		b.userState[stateKey{chatID, from.ID}] = UserState{Action: "digest_time", Data: make(map[string]interface{})}
+		b.askTime(chatID, i18n.T(lang, "digest.ask_time"))
+		return
+	default:
+		return
+	}
+
+	if err := b.users.SetDigest(chatID, daily, weekly); err != nil {
+		log.Printf("Gagal menyimpan digest %d: %v\n", chatID, err)
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.scheduleDigest(chatID)
+	b.sendDigestSettings(chatID, messageID)
+}
+
+// setDigestTime stores the time typed or picked after "digest:time" and
+// turns the daily digest on.
+func (b *Bot) setDigestTime(chatID int64, clock string) {
+	lang := b.lang(chatID)
+	_, weekly := b.users.Digest(chatID)
+	if err := b.users.SetDigest(chatID, clock, weekly); err != nil {
+		log.Printf("Gagal menyimpan digest %d: %v\n", chatID, err)
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.scheduleDigest(chatID)
+	b.sendMessage(chatID, i18n.T(lang, "digest.time_saved", code(clock)))
+	b.sendDigestSettings(chatID, 0)
+}
+
+func getDigestKeyboard(lang i18n.Lang, daily, weekly bool) tgbotapi.InlineKeyboardMarkup {
+	toggle := func(key string, on bool) string {
+		if on {
+			return "✅ " + i18n.T(lang, key)
+		}
+		return "⬜ " + i18n.T(lang, key)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(toggle("digest.button.daily", daily), "digest:daily"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "digest.button.time"), "digest:time"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(toggle("digest.button.weekly", weekly), "digest:weekly"),
+		),
+	)
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"bytes"
+	"log"
+	"sort"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/ical"
+)
+
+// exportSchedules sends the user's schedules, or with a #tag argument only
+// the ones tagged with it, as an iCalendar document that can be opened by
+// phone and desktop calendar apps.
+func (b *Bot) exportSchedules(chatID int64, args []string) {
+	lang := b.lang(chatID)
+	tag, _, err := splitTagFilter(args)
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	schedules := filterByTag(b.storage.GetUserSchedules(chatID), tag)
+	if len(schedules) == 0 {
+		if tag != "" {
+			b.sendMessage(chatID, i18n.T(lang, "tag.none", esc(tag)))
+			return
+		}
+		b.sendMessage(chatID, i18n.T(lang, "schedules.none"))
+		return
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Title < schedules[j].Title })
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, schedules, b.holidays, b.loc(chatID), time.Now()); err != nil {
+		log.Printf("Gagal membuat file ics untuk %d: %v\n", chatID, err)
+		b.sendMessage(chatID, i18n.T(lang, "export.failed"))
+		return
+	}
+
+	name := "turschedule.ics"
+	if tag != "" {
+		name = "turschedule-" + tag + ".ics"
+	}
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  name,
+		Bytes: buf.Bytes(),
+	})
+	doc.Caption = i18n.N(lang, "export.caption", len(schedules), len(schedules))
+	doc.ParseMode = tgbotapi.ModeHTML
+	if _, err := b.api.Send(doc); err != nil {
+		log.Printf("Gagal mengirim file ics ke %d: %v\n", chatID, err)
+		b.sendMessage(chatID, i18n.T(lang, "export.send_failed"))
+	}
+}
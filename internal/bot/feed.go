@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"turschedule/internal/i18n"
+)
+
+// sendFeed replies with the user's calendar subscription link. With reset
+// the token is rotated first, so previously shared links stop working.
+func (b *Bot) sendFeed(chatID int64, reset bool) {
+	lang := b.lang(chatID)
+	if b.publicURL == "" {
+		b.sendMessage(chatID, i18n.T(lang, "feed.disabled"))
+		return
+	}
+
+	var (
+		token string
+		err   error
+	)
+	if reset {
+		token, err = b.users.ResetFeedToken(chatID)
+	} else {
+		token, err = b.users.FeedToken(chatID)
+	}
+	if err != nil {
+		log.Printf("Gagal membuat token feed untuk %d: %v\n", chatID, err)
+		b.sendMessage(chatID, i18n.T(lang, "feed.failed"))
+		return
+	}
+
+	feedURL := fmt.Sprintf("%s/feed/%s.ics", b.publicURL, token)
+	text := i18n.T(lang, "feed.link", esc(feedURL))
+	if rest, ok := strings.CutPrefix(feedURL, "https://"); ok {
+		text += i18n.T(lang, "feed.webcal", esc(rest))
+	}
+	text += i18n.T(lang, "feed.howto")
+	if reset {
+		text = i18n.T(lang, "feed.reset") + text
+	} else {
+		text += i18n.T(lang, "feed.warning")
+	}
+
+	b.sendMessage(chatID, text)
+}
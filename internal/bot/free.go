@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
+	"turschedule/internal/storage"
+)
+
+// durationChoices are the lengths offered as buttons when asking for a
+// duration, in minutes.
+var durationChoices = []int{30, 60, 90, 120}
+
+// warnConflicts tells chatID which other schedules overlap schedule.
+func (b *Bot) warnConflicts(chatID int64, schedule *storage.Schedule) {
+	conflicts := b.storage.Conflicts(schedule)
+	if len(conflicts) == 0 {
+		return
+	}
+	b.sendMessage(chatID, renderConflicts(b.lang(chatID), schedule, conflicts))
+}
+
+// workHours returns the range /free searches for chatID.
+func (b *Bot) workHours(chatID int64) storage.Slot {
+	if hours := b.users.WorkHours(chatID); hours != "" {
+		if slot, err := storage.ParseSlot(hours); err == nil {
+			return slot
+		}
+	}
+	return b.defaultWorkHours
+}
+
+// sendFree handles /free [hari] [08:00-17:00]: the free slots on the next
+// such day within the working hours, or the given range.
+func (b *Bot) sendFree(chatID int64, args []string) {
+	lang := b.lang(chatID)
+	now := time.Now().In(b.loc(chatID))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// A trailing range overrides the working hours; anything else, such as
+	// "2026-11-02", is left for the day
+	within := b.workHours(chatID)
+	if len(args) > 0 {
+		if slot, err := storage.ParseSlot(args[len(args)-1]); err == nil {
+			within, args = slot, args[:len(args)-1]
+		}
+	}
+
+	day := today
+	if text := strings.Join(args, " "); text != "" {
+		if weekday, ok := i18n.ParseDay(text); ok {
+			wd, _ := storage.ParseWeekday(weekday)
+			day = today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7)
+		} else if date, ok := nlp.ParseDate(text, now); ok {
+			day = date
+		} else {
+			b.sendMessage(chatID, i18n.T(lang, "free.usage"))
+			return
+		}
+	}
+
+	// Busy spans come from the occurrences that actually happen that day,
+	// so paused, skipped and holiday dates leave their time free
+	var busy []storage.Slot
+	for _, o := range b.occurrencesBetween(chatID, day, day.AddDate(0, 0, 1), "") {
+		if o.Holiday == "" {
+			busy = append(busy, o.Schedule.Span())
+		}
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "free.header", esc(i18n.FormatWeekdayDate(lang, day)), code(renderSlot(within))))
+	free := storage.FreeSlots(within, busy)
+	if len(free) == 0 {
+		text.WriteString("\n" + i18n.T(lang, "free.none"))
+	}
+	for _, slot := range free {
+		length := time.Duration(slot.End-slot.Start) * time.Minute
+		text.WriteString("\n🟢 " + code(renderSlot(slot)) + " (" + esc(i18n.Duration(lang, length)) + ")")
+	}
+	b.sendMessage(chatID, text.String())
+}
+
+// setWorkHours handles /workhours [08:00-17:00|reset].
+func (b *Bot) setWorkHours(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "workhours.current", code(renderSlot(b.workHours(chatID)))))
+		return
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+
+	hours := ""
+	if args[0] != "reset" {
+		slot, err := storage.ParseSlot(strings.Join(args, ""))
+		if err != nil {
+			b.sendMessage(chatID, renderError(lang, err))
+			return
+		}
+		hours = slot.String()
+	}
+	if err := b.users.SetWorkHours(chatID, hours); err != nil {
+		log.Printf("Gagal menyimpan jam kerja %d: %v\n", chatID, err)
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, "workhours.saved", code(renderSlot(b.workHours(chatID)))))
+}
+
+// parseDurationInput reads the answer to ask.duration, where the no
+// duration button or "-" clear it. The event must end by midnight.
+func parseDurationInput(text, start string) (int, bool) {
+	if text == "-" || i18n.Match(text, "button.no_duration") {
+		return 0, true
+	}
+	duration, ok := nlp.ParseDuration(text, start)
+	if !ok || !fitsInDay(start, duration) {
+		return 0, false
+	}
+	return duration, true
+}
+
+// fitsInDay reports whether an event starting at start ("HH:MM") and
+// lasting duration minutes ends by midnight.
+func fitsInDay(start string, duration int) bool {
+	s := storage.Schedule{Time: start, Duration: duration}
+	return s.Span().End <= storage.MinutesPerDay
+}
+
+func getDurationKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	var row []tgbotapi.KeyboardButton
+	for _, minutes := range durationChoices {
+		row = append(row, tgbotapi.NewKeyboardButton(i18n.Duration(lang, time.Duration(minutes)*time.Minute)))
+	}
+	return tgbotapi.NewReplyKeyboard(
+		row,
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.no_duration")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+)
+
+// isPrivate reports whether chatID is a one-to-one chat. Telegram gives
+// users positive IDs and groups negative ones.
+func isPrivate(chatID int64) bool {
+	return chatID > 0
+}
+
+// isAdmin reports whether userID may change the schedules of chatID. In a
+// private chat that is always the user; in a group only its creator and
+// administrators may edit, pause or delete the shared schedules.
+func (b *Bot) isAdmin(chatID, userID int64) bool {
+	if isPrivate(chatID) {
+		return true
+	}
+
+	member, err := b.api.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		log.Printf("Gagal memeriksa admin %d di chat %d: %v\n", userID, chatID, err)
+		return false
+	}
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+// requireAdmin tells non-admin group members that they cannot make the
+// change they asked for.
+func (b *Bot) requireAdmin(chatID, userID int64) bool {
+	if b.isAdmin(chatID, userID) {
+		return true
+	}
+	b.sendReplyMessage(chatID, i18n.T(b.lang(chatID), "group.admin_only"))
+	return false
+}
+
+// displayName is how the creator of a group schedule is credited.
+func displayName(u *tgbotapi.User) string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" && u.UserName != "" {
+		name = "@" + u.UserName
+	}
+	return name
+}
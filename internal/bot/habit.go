@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// statsWeeks is how many weeks the /stats heatmap covers.
+const statsWeeks = 8
+
+// sendStats handles /stats [judul]: an overview of the streaks and
+// completion rates of every recurring schedule, or the weekly heatmap of
+// one of them.
+func (b *Bot) sendStats(chatID int64, args []string) {
+	lang := b.lang(chatID)
+	now := time.Now().In(b.loc(chatID))
+
+	if title := strings.Join(args, " "); title != "" {
+		schedule, err := b.storage.GetScheduleByTitle(chatID, title)
+		if err != nil {
+			b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+			return
+		}
+		if schedule.ReminderType != storage.ReminderRecurring {
+			b.sendMessage(chatID, i18n.T(lang, "stats.once"))
+			return
+		}
+		b.sendMessage(chatID, renderHabit(lang, schedule, now))
+		return
+	}
+
+	var schedules []*storage.Schedule
+	for _, s := range b.storage.GetUserSchedules(chatID) {
+		if s.ReminderType == storage.ReminderRecurring {
+			schedules = append(schedules, s)
+		}
+	}
+	if len(schedules) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "stats.empty"))
+		return
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Title < schedules[j].Title })
+
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "stats.header"))
+	today := now.Format(storage.DateLayout)
+	for _, s := range schedules {
+		h := s.Habit(today)
+		text.WriteString("\n📌 " + bold(s.Title) + "\n   " + i18n.T(lang, "stats.line", h.Current, h.Longest, h.Done, h.Fired, h.Rate()) + "\n")
+	}
+	text.WriteString("\n" + i18n.T(lang, "stats.hint"))
+	b.sendMessage(chatID, text.String())
+}
+
+// renderHabit renders the statistics of s with a heatmap of the last
+// statsWeeks weeks, one row per week from Monday to Sunday.
+func renderHabit(lang i18n.Lang, s *storage.Schedule, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	start := monday.AddDate(0, 0, -7*(statsWeeks-1))
+	created := time.Date(s.CreatedAt.Year(), s.CreatedAt.Month(), s.CreatedAt.Day(), 0, 0, 0, 0, now.Location())
+	hour, minute, _ := storage.ParseClock(s.Time)
+
+	h := s.Habit(today.Format(storage.DateLayout))
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "stats.detail", esc(s.Title), h.Current, h.Longest, h.Done, h.Fired, h.Rate()) + "\n\n")
+	for week := start; !week.After(monday); week = week.AddDate(0, 0, 7) {
+		text.WriteString(code(fmt.Sprintf("%02d/%02d", week.Day(), int(week.Month()))) + " ")
+		for day := week; day.Before(week.AddDate(0, 0, 7)); day = day.AddDate(0, 0, 1) {
+			date := day.Format(storage.DateLayout)
+			at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+			switch {
+			case s.IsDone(date):
+				text.WriteString("✅")
+			case s.HasFired(date) && day.Equal(today):
+				text.WriteString("⏳")
+			case s.HasFired(date):
+				text.WriteString("❌")
+			case day.Before(created) || !contains(s.Days, day.Weekday().String()):
+				text.WriteString("⬜")
+			case at.After(now):
+				text.WriteString("▫️")
+			default:
+				text.WriteString("➖")
+			}
+		}
+		text.WriteString("\n")
+	}
+	text.WriteString("\n" + i18n.T(lang, "stats.legend"))
+	return text.String()
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// firesOn reports whether s notifies for its occurrence at the given time:
+// it is neither paused nor skipped, and the date is not a holiday in one of
+// the calendars s observes.
+func (b *Bot) firesOn(s *storage.Schedule, at time.Time) bool {
+	if !s.FiresOn(at) {
+		return false
+	}
+	if name, ok := b.holidays.Holiday(s, at); ok {
+		log.Printf("Jadwal %s dilewati karena libur %s\n", s.ID, name)
+		return false
+	}
+	return true
+}
+
+// holidayCalendar handles /holiday <judul> <kalender>, which toggles whether
+// a schedule skips the dates of a holiday calendar. Without arguments it
+// lists the available calendars.
+func (b *Bot) holidayCalendar(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	names := b.holidays.Names()
+	if len(names) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "holiday.no_calendars"))
+		return
+	}
+	if len(args) < 2 {
+		b.sendHolidayCalendars(chatID)
+		return
+	}
+
+	name := strings.ToLower(args[len(args)-1])
+	if !b.holidays.Has(name) {
+		b.sendMessage(chatID, i18n.T(lang, "holiday.unknown", esc(name), esc(strings.Join(names, ", "))))
+		return
+	}
+	schedule, ok := b.findForChange(chatID, from, strings.Join(args[:len(args)-1], " "))
+	if !ok {
+		return
+	}
+	if schedule.ReminderType == storage.ReminderOnce {
+		b.sendMessage(chatID, i18n.T(lang, "holiday.once"))
+		return
+	}
+
+	key := "holiday.added"
+	updated := *schedule
+	updated.Holidays = nil
+	for _, h := range schedule.Holidays {
+		if h != name {
+			updated.Holidays = append(updated.Holidays, h)
+		}
+	}
+	if contains(schedule.Holidays, name) {
+		key = "holiday.removed"
+	} else {
+		updated.Holidays = append(updated.Holidays, name)
+	}
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, key, bold(schedule.Title), code(name)))
+}
+
+// sendHolidayCalendars lists the holiday calendars with their next holiday.
+func (b *Bot) sendHolidayCalendars(chatID int64) {
+	lang := b.lang(chatID)
+	now := time.Now().In(b.loc(chatID))
+
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "holiday.header"))
+	for _, name := range b.holidays.Names() {
+		text.WriteString("\n• " + code(name))
+		if next := b.holidays.Upcoming(name, now, 1); len(next) > 0 {
+			day, _ := time.ParseInLocation(storage.DateLayout, next[0].Date, now.Location())
+			text.WriteString(" — " + i18n.T(lang, "holiday.next", esc(next[0].Name), esc(i18n.FormatWeekdayDate(lang, day))))
+		}
+	}
+	text.WriteString("\n\n" + i18n.T(lang, "holiday.usage"))
+	b.sendMessage(chatID, text.String())
+}
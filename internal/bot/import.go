@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/ical"
+	"turschedule/internal/storage"
+)
+
+// maxImportSize limits uploaded calendar files; timetables are a few KB.
+const maxImportSize = 1 << 20
+
+// handleDocument previews the schedules found in an uploaded .ics file and
+// waits for the user to confirm before creating them. Other files are
+// answered with a hint in private chats and ignored in groups, where members
+// share all kinds of documents.
+func (b *Bot) handleDocument(chatID int64, from *tgbotapi.User, doc *tgbotapi.Document) {
+	lang := b.lang(chatID)
+	if !strings.EqualFold(filepath.Ext(doc.FileName), ".ics") && doc.MimeType != "text/calendar" {
+		if isPrivate(chatID) {
+			b.sendMessage(chatID, i18n.T(lang, "import.only_ics"))
+		}
+		return
+	}
+	if doc.FileSize > maxImportSize {
+		b.sendMessage(chatID, i18n.T(lang, "import.too_large"))
+		return
+	}
+
+	url, err := b.api.GetFileDirectURL(doc.FileID)
+	if err != nil {
+		log.Printf("Gagal mengambil file %s: %v\n", doc.FileID, err)
+		b.sendMessage(chatID, i18n.T(lang, "import.download_failed"))
+		return
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Printf("Gagal mengunduh file %s: %v\n", doc.FileID, err)
+		b.sendMessage(chatID, i18n.T(lang, "import.download_failed"))
+		return
+	}
+	defer resp.Body.Close()
+
+	drafts, skipped, err := ical.Decode(io.LimitReader(resp.Body, maxImportSize), b.loc(chatID), time.Now())
+	if errors.Is(err, ical.ErrNotCalendar) {
+		b.sendMessage(chatID, i18n.T(lang, "import.not_calendar"))
+		return
+	}
+	if err != nil {
+		log.Printf("Gagal membaca file %s: %v\n", doc.FileID, err)
+		b.sendMessage(chatID, i18n.T(lang, "import.read_failed"))
+		return
+	}
+	if len(drafts) == 0 {
+		text := i18n.T(lang, "import.nothing")
+		if len(skipped) > 0 {
+			text += "\n\n" + renderSkipped(lang, skipped)
+		}
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.N(lang, "import.found", len(drafts), len(drafts)))
+	collisions := 0
+	seen := make(map[string]bool)
+	for i, s := range drafts {
+		s.UserID = chatID
+		s.CreatedBy = from.ID
+		s.CreatorName = displayName(from)
+		text.WriteString(renderImportItem(lang, i+1, s))
+		if b.storage.IsTitleExists(chatID, s.Title) || seen[s.Title] {
+			text.WriteString(i18n.T(lang, "import.title_exists"))
+			collisions++
+		}
+		seen[s.Title] = true
+	}
+	if len(skipped) > 0 {
+		text.WriteString(i18n.T(lang, "import.not_imported") + renderSkipped(lang, skipped))
+	}
+	if collisions == len(drafts) {
+		text.WriteString(i18n.T(lang, "import.all_exist"))
+		b.sendMessage(chatID, text.String())
+		return
+	}
+	text.WriteString(i18n.N(lang, "import.save", len(drafts)-collisions, len(drafts)-collisions))
+
+	b.userState[stateKey{chatID, from.ID}] = UserState{
+		Action: "import_confirm",
+		Data:   map[string]interface{}{"drafts": drafts},
+	}
+	b.sendMessageWithKeyboard(chatID, text.String(), getImportConfirmKeyboard(lang))
+}
+
+// saveImport stores the confirmed drafts, skipping titles that already exist.
+func (b *Bot) saveImport(chatID int64, from *tgbotapi.User, drafts []*storage.Schedule) {
+	saved, skipped := 0, 0
+	for _, s := range drafts {
+		if b.storage.IsTitleExists(chatID, s.Title) {
+			skipped++
+			continue
+		}
+		if err := b.storage.AddSchedule(s); err != nil {
+			log.Printf("Gagal menyimpan jadwal impor %q: %v\n", s.Title, err)
+			skipped++
+			continue
+		}
+		b.scheduleReminder(s)
+		saved++
+	}
+
+	lang := b.lang(chatID)
+	text := i18n.N(lang, "import.saved", saved, saved)
+	if skipped > 0 {
+		text += i18n.N(lang, "import.skipped", skipped, skipped)
+	}
+	b.sendMessage(chatID, text)
+}
+
+func getImportConfirmKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	return tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.save_all")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
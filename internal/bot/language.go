@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+)
+
+// lang returns the interface language of chatID.
+func (b *Bot) lang(chatID int64) i18n.Lang {
+	if lang, ok := i18n.Parse(b.users.Language(chatID)); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// detectLanguage stores the language of the user's Telegram client the first
+// time they write to the bot. A language chosen with /language is kept.
+func (b *Bot) detectLanguage(chatID int64, from *tgbotapi.User) {
+	if from == nil || b.users.Language(chatID) != "" {
+		return
+	}
+	if err := b.users.SetLanguage(chatID, string(i18n.Detect(from.LanguageCode))); err != nil {
+		log.Printf("Gagal menyimpan bahasa untuk %d: %v\n", chatID, err)
+	}
+}
+
+// setLanguage switches the interface language to the one named by choice,
+// which is either a code such as "en" or a button of the language keyboard.
+func (b *Bot) setLanguage(chatID int64, choice string) {
+	lang, ok := i18n.Parse(choice)
+	if !ok {
+		for _, l := range i18n.Languages {
+			if choice == i18n.T(l, "language.button") || choice == l.Name() {
+				lang, ok = l, true
+				break
+			}
+		}
+	}
+	if !ok {
+		b.sendMessage(chatID, i18n.T(b.lang(chatID), "language.invalid"))
+		return
+	}
+
+	if err := b.users.SetLanguage(chatID, string(lang)); err != nil {
+		log.Printf("Gagal menyimpan bahasa untuk %d: %v\n", chatID, err)
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, "language.set"))
+}
+
+func getLanguageKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	var row []tgbotapi.KeyboardButton
+	for _, l := range i18n.Languages {
+		row = append(row, tgbotapi.NewKeyboardButton(i18n.T(l, "language.button")))
+	}
+	return tgbotapi.NewReplyKeyboard(
+		row,
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
@@ -0,0 +1,295 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// listPageSize keeps each /list page well below Telegram's 4096 character
+// message limit even with long notes.
+const listPageSize = 5
+
+// Sort orders accepted by /list and its sort buttons.
+const (
+	listSortNext    = "next"
+	listSortTitle   = "title"
+	listSortCreated = "created"
+)
+
+var listSorts = []string{listSortNext, listSortTitle, listSortCreated}
+
+func isListSort(sortBy string) bool {
+	return contains(listSorts, sortBy)
+}
+
+// sortSchedules orders schedules by their next occurrence after now, by title
+// or newest first. Ties fall back to the title so the order is stable.
+func sortSchedules(schedules []*storage.Schedule, sortBy string, now time.Time) {
+	next := make(map[string]time.Time, len(schedules))
+	for _, s := range schedules {
+		if at, ok := s.NextOccurrence(now); ok {
+			next[s.ID] = at
+		}
+	}
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		a, b := schedules[i], schedules[j]
+		switch sortBy {
+		case listSortNext:
+			an, aok := next[a.ID]
+			bn, bok := next[b.ID]
+			if aok != bok {
+				// Schedules that no longer fire go last
+				return aok
+			}
+			if !an.Equal(bn) {
+				return an.Before(bn)
+			}
+		case listSortCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+		if at, bt := strings.ToLower(a.Title), strings.ToLower(b.Title); at != bt {
+			return at < bt
+		}
+		return a.ID < b.ID
+	})
+}
+
+// sendList shows one page of the user's schedules, optionally only those
+// tagged tag, with inline buttons for paging, sorting and acting on each
+// schedule. A non-zero messageID edits that message in place instead of
+// sending a new one.
+func (b *Bot) sendList(chatID int64, sortBy, tag string, page, messageID int) {
+	lang := b.lang(chatID)
+	schedules := filterByTag(b.storage.GetUserSchedules(chatID), tag)
+	if len(schedules) == 0 {
+		text := i18n.T(lang, "schedules.none")
+		if tag != "" {
+			text = i18n.T(lang, "tag.none", esc(tag))
+		}
+		if messageID != 0 {
+			b.editMessage(chatID, messageID, text, nil)
+			return
+		}
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	sortSchedules(schedules, sortBy, now)
+
+	pages := (len(schedules) + listPageSize - 1) / listPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	start := page * listPageSize
+	end := start + listPageSize
+	if end > len(schedules) {
+		end = len(schedules)
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "list.header", len(schedules), i18n.T(lang, "list.sort."+sortBy), page+1, pages))
+	if tag != "" {
+		text.WriteString(i18n.T(lang, "tag.filter", esc(tag)))
+	}
+	for i, s := range schedules[start:end] {
+		text.WriteString(renderListItem(lang, start+i+1, s, b.holidays, now))
+	}
+	text.WriteString("\n" + i18n.T(lang, "list.legend"))
+
+	keyboard := getListKeyboard(lang, schedules[start:end], start, sortBy, tag, page, pages, now)
+	if messageID != 0 {
+		b.editMessage(chatID, messageID, text.String(), &keyboard)
+		return
+	}
+	b.sendMessageWithKeyboard(chatID, text.String(), keyboard)
+}
+
+// handleListCallback handles "list:<sort>:<page>:<tag>" paging and sorting.
+func (b *Bot) handleListCallback(chatID int64, messageID int, value string) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) < 2 || !isListSort(parts[0]) {
+		return
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+	var tag string
+	if len(parts) == 3 {
+		tag = parts[2]
+	}
+	b.sendList(chatID, parts[0], tag, page, messageID)
+}
+
+// handleScheduleCallback handles the per-schedule buttons of /list, encoded
+// as "sch:<action>:<sort>:<page>:<tag>:<id>" so the list can be redrawn on
+// the same page afterwards.
+func (b *Bot) handleScheduleCallback(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	parts := strings.SplitN(value, ":", 5)
+	if len(parts) != 5 || !isListSort(parts[1]) {
+		return
+	}
+	action, sortBy, tag, id := parts[0], parts[1], parts[3], parts[4]
+	page, _ := strconv.Atoi(parts[2])
+	lang := b.lang(chatID)
+
+	schedule, err := b.storage.GetSchedule(id)
+	if err != nil || schedule.UserID != chatID {
+		b.sendReplyMessage(chatID, i18n.T(lang, "list.gone"))
+		b.sendList(chatID, sortBy, tag, page, messageID)
+		return
+	}
+
+	// Duplicating only adds a schedule, which every member may do
+	if action != "dup" && !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+
+	switch action {
+	case "edit":
+		updated := *schedule
+		b.userState[stateKey{chatID, from.ID}] = UserState{
+			Action: "edit_field",
+			Data:   map[string]interface{}{"schedule": &updated},
+		}
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "list.editing", bold(schedule.Title))+"\n"+i18n.T(lang, "ask.field"), getFieldKeyboard(lang))
+
+	case "del":
+		back := fmt.Sprintf("list:%s:%d:%s", sortBy, page, tag)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.delete_yes"), scheduleCallback("delok", sortBy, tag, page, id)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.back"), back),
+		))
+		b.editMessage(chatID, messageID, i18n.T(lang, "list.delete_confirm", bold(schedule.Title)), &keyboard)
+
+	case "delok":
+		if err := b.storage.DeleteSchedule(id); err != nil {
+			b.sendReplyMessage(chatID, i18n.T(lang, "delete.failed"))
+		} else {
+			b.Remove(schedule)
+		}
+		b.sendList(chatID, sortBy, tag, page, messageID)
+
+	case "pause":
+		// Resuming from the list keeps the dates skipped one by one
+		updated := *schedule
+		if updated.IsPausedOn(time.Now().In(b.loc(chatID)).Format(storage.DateLayout)) {
+			updated.Paused, updated.PausedUntil = false, ""
+		} else {
+			updated.Pause(time.Time{})
+		}
+		if err := b.storage.UpdateSchedule(&updated); err != nil {
+			b.sendReplyMessage(chatID, renderError(lang, err))
+		}
+		b.sendList(chatID, sortBy, tag, page, messageID)
+
+	case "dup":
+		if _, err := b.duplicateSchedule(schedule, b.copyTitle(lang, schedule), from); err != nil {
+			log.Printf("Gagal menduplikasi jadwal %s: %v\n", id, err)
+			b.sendReplyMessage(chatID, renderError(lang, err))
+		}
+		b.sendList(chatID, sortBy, tag, page, messageID)
+	}
+}
+
+// copyTitle picks an unused title for a copy of schedule, e.g. "Rapat
+// (salinan)", then "Rapat (salinan 2)".
+func (b *Bot) copyTitle(lang i18n.Lang, schedule *storage.Schedule) string {
+	title := i18n.T(lang, "list.copy_title", schedule.Title)
+	for n := 2; b.storage.IsTitleExists(schedule.UserID, title); n++ {
+		title = i18n.T(lang, "list.copy_title_n", schedule.Title, n)
+	}
+	return title
+}
+
+// duplicateSchedule stores a copy of schedule under a new title, credited to
+// from, and registers its reminders. Sent reminders, the paused state,
+// subscribers, attendance and habit history are not copied.
+func (b *Bot) duplicateSchedule(schedule *storage.Schedule, title string, from *tgbotapi.User) (*storage.Schedule, error) {
+	copied := &storage.Schedule{
+		UserID:        schedule.UserID,
+		CreatedBy:     from.ID,
+		CreatorName:   displayName(from),
+		Title:         title,
+		Time:          schedule.Time,
+		Duration:      schedule.Duration,
+		Days:          append([]string(nil), schedule.Days...),
+		Note:          schedule.Note,
+		Tags:          append([]string(nil), schedule.Tags...),
+		ReminderType:  schedule.ReminderType,
+		ReminderTimes: append([]int(nil), schedule.ReminderTimes...),
+		ReminderSent:  make(map[string]bool),
+		QuietMode:     schedule.QuietMode,
+		NagEvery:      schedule.NagEvery,
+		NagMax:        schedule.NagMax,
+		Holidays:      append([]string(nil), schedule.Holidays...),
+	}
+	if err := b.storage.AddSchedule(copied); err != nil {
+		return nil, err
+	}
+	b.scheduleReminder(copied)
+	return copied, nil
+}
+
+// scheduleCallback encodes a /list button. storage.MaxTagLength and
+// storage.MaxIDLength keep the result within Telegram's 64 byte limit for
+// callback data up to page 999.
+func scheduleCallback(action, sortBy, tag string, page int, id string) string {
+	return fmt.Sprintf("sch:%s:%s:%d:%s:%s", action, sortBy, page, tag, id)
+}
+
+func getListKeyboard(lang i18n.Lang, schedules []*storage.Schedule, offset int, sortBy, tag string, page, pages int, now time.Time) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, s := range schedules {
+		n := offset + i + 1
+		pause := fmt.Sprintf("⏸️ %d", n)
+		if s.IsPausedOn(now.Format(storage.DateLayout)) {
+			pause = fmt.Sprintf("▶️ %d", n)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("✏️ %d", n), scheduleCallback("edit", sortBy, tag, page, s.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🗑️ %d", n), scheduleCallback("del", sortBy, tag, page, s.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(pause, scheduleCallback("pause", sortBy, tag, page, s.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("📄 %d", n), scheduleCallback("dup", sortBy, tag, page, s.ID)),
+		))
+	}
+
+	var sortRow []tgbotapi.InlineKeyboardButton
+	for _, by := range listSorts {
+		label := i18n.T(lang, "list.button."+by)
+		if by == sortBy {
+			label = "• " + label
+		}
+		sortRow = append(sortRow, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("list:%s:0:%s", by, tag)))
+	}
+	rows = append(rows, sortRow)
+
+	if pages > 1 {
+		var nav []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("list:%s:%d:%s", sortBy, page-1, tag)))
+		}
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, pages), fmt.Sprintf("list:%s:%d:%s", sortBy, page, tag)))
+		if page < pages-1 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("list:%s:%d:%s", sortBy, page+1, tag)))
+		}
+		rows = append(rows, nav)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// doneButtons adds the "Selesai" button for the occurrence of s on date
+// below the attendance buttons in rsvp, if any. Recurring schedules get it
+// to track the habit, priority schedules to stop their nag.
+func doneButtons(s *storage.Schedule, date string, rsvp func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup) func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	if !s.IsPriority() && s.ReminderType != storage.ReminderRecurring {
+		return rsvp
+	}
+	return func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+		done := tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.done"), "done:"+date+":"+s.ID),
+		)
+		if rsvp == nil {
+			return tgbotapi.NewInlineKeyboardMarkup(done)
+		}
+		keyboard := rsvp(lang)
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, done)
+		return keyboard
+	}
+}
+
+// startNag persists the nag of a priority schedule whose main notification
+// for date was just sent and arms its first repeat.
+func (b *Bot) startNag(s *storage.Schedule, date string) {
+	if !s.IsPriority() {
+		return
+	}
+	next := time.Now().Add(time.Duration(s.NagEvery) * time.Minute)
+	if err := b.storage.StartNag(s.ID, date, next); err != nil {
+		log.Printf("Gagal menyimpan pengulangan %s: %v\n", s.ID, err)
+		return
+	}
+	b.armNag(s.ID, storage.Nag{Date: date, Next: next})
+}
+
+// armNag sends the repeat described by nag when it is due; a repeat that
+// came due while the bot was down goes out right away.
+func (b *Bot) armNag(id string, nag storage.Nag) {
+	time.AfterFunc(time.Until(nag.Next), func() {
+		b.sendNag(id, nag)
+	})
+}
+
+// sendNag repeats the main notification of schedule id unless the nag was
+// acknowledged or replaced in the meantime, then arms the next repeat. In a
+// recipient's quiet hours a repeat is sent silently, or not at all when the
+// schedule delays or skips its reminders: the next repeat follows anyway.
+func (b *Bot) sendNag(id string, nag storage.Nag) {
+	schedule, err := b.storage.GetSchedule(id)
+	if err != nil {
+		return
+	}
+	pending := schedule.PendingNag
+	if pending == nil || pending.Date != nag.Date || pending.Sent != nag.Sent {
+		return
+	}
+
+	n, limit := nag.Sent+1, schedule.NagLimit()
+	buttons := doneButtons(schedule, nag.Date, nil)
+	now := time.Now()
+	for _, chatID := range b.recipients(schedule) {
+		text := i18n.T(b.lang(chatID), "nag.repeat", bold(schedule.Title), code(schedule.Time), n, limit)
+		_, quiet := b.quietUntil(chatID, now)
+		switch {
+		case !quiet:
+			b.sendNotification(chatID, text, buttons, false)
+		case schedule.QuietAction() == storage.QuietSilent:
+			b.sendNotification(chatID, text, buttons, true)
+		default:
+			log.Printf("Pengulangan %s untuk %d dilewati karena jam tenang\n", id, chatID)
+		}
+	}
+
+	next, err := b.storage.AdvanceNag(id, nag.Date, nag.Sent, time.Now().Add(time.Duration(schedule.NagEvery)*time.Minute))
+	if err != nil {
+		log.Printf("Gagal menyimpan pengulangan %s: %v\n", id, err)
+		return
+	}
+	if next != nil {
+		b.armNag(id, *next)
+		return
+	}
+	b.finishOnce(schedule)
+}
+
+// resumeNags re-arms the nags that were pending when the bot stopped.
+func (b *Bot) resumeNags() {
+	for _, schedule := range b.storage.GetAllSchedules() {
+		if schedule.PendingNag != nil {
+			b.armNag(schedule.ID, *schedule.PendingNag)
+		}
+	}
+}
+
+// handleDoneCallback handles "Selesai", sent as "done:<date>:<id>": it
+// stops the nag of the occurrence and records it as completed. The
+// attendance buttons of the message stay.
+func (b *Bot) handleDoneCallback(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	date, id, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	lang := b.lang(chatID)
+
+	schedule, err := b.storage.GetSchedule(id)
+	if err != nil || (schedule.UserID != chatID && !schedule.IsSubscriber(chatID)) {
+		b.editMessageKeyboard(chatID, messageID, nil)
+		return
+	}
+
+	stopped, err := b.storage.StopNag(id, date)
+	if err != nil {
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+	completed := false
+	if schedule.ReminderType == storage.ReminderRecurring {
+		if completed, err = b.storage.Complete(id, date); err != nil {
+			b.sendReplyMessage(chatID, renderError(lang, err))
+			return
+		}
+	}
+	if rsvp := rsvpButtons(schedule, date); rsvp != nil {
+		keyboard := rsvp(lang)
+		b.editMessageKeyboard(chatID, messageID, &keyboard)
+	} else {
+		b.editMessageKeyboard(chatID, messageID, nil)
+	}
+	if !stopped && !completed {
+		return
+	}
+
+	text := i18n.T(lang, "nag.done", bold(schedule.Title), esc(displayName(from)))
+	if completed {
+		today := time.Now().In(b.loc(schedule.UserID)).Format(storage.DateLayout)
+		if streak := schedule.Habit(today).Current; streak > 1 {
+			text += "\n" + i18n.N(lang, "habit.streak", streak, streak)
+		}
+	}
+	b.sendReplyMessage(chatID, text)
+	b.finishOnce(schedule)
+}
+
+// setPriority handles /priority <judul> [menit] [maks] and /priority
+// <judul> off. A priority schedule repeats its main notification until
+// someone taps "Selesai".
+func (b *Bot) setPriority(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "priority.usage"))
+		return
+	}
+
+	// The title is the longest prefix naming a schedule, so both
+	// "/priority Minum Obat" and "/priority Minum Obat 10 3" work
+	var (
+		schedule *storage.Schedule
+		options  []string
+	)
+	for i := len(args); i > 0 && schedule == nil; i-- {
+		if s, err := b.storage.GetScheduleByTitle(chatID, strings.Join(args[:i], " ")); err == nil {
+			schedule, options = s, args[i:]
+		}
+	}
+	if schedule == nil {
+		b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+		return
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+
+	every, limit := storage.DefaultNagEvery, storage.DefaultNagMax
+	switch {
+	case len(options) == 1 && (strings.EqualFold(options[0], "off") || strings.EqualFold(options[0], "mati")):
+		every, limit = 0, 0
+	case len(options) > 2:
+		b.sendMessage(chatID, i18n.T(lang, "priority.usage"))
+		return
+	default:
+		values := []*int{&every, &limit}
+		for i, option := range options {
+			n, err := strconv.Atoi(option)
+			if err != nil || n <= 0 {
+				b.sendMessage(chatID, i18n.T(lang, "priority.usage"))
+				return
+			}
+			*values[i] = n
+		}
+	}
+
+	updated := *schedule
+	updated.NagEvery, updated.NagMax = every, limit
+	if every == 0 {
+		updated.PendingNag = nil
+	}
+	if err := updated.Validate(); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	if every == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "priority.off", bold(schedule.Title)))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, "priority.on", bold(schedule.Title), every, limit))
+}
@@ -0,0 +1,252 @@
+package bot
+
+import (
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
+	"turschedule/internal/storage"
+)
+
+// startDraft interprets a free-text description and either asks for the
+// fields it could not find or shows the interpretation for confirmation.
+func (b *Bot) startDraft(chatID int64, from *tgbotapi.User, text string) {
+	text, tags := extractHashtags(text)
+	r := nlp.Parse(text, time.Now().In(b.loc(chatID)))
+
+	data := map[string]interface{}{
+		"title":        r.Title,
+		"time":         r.Time,
+		"duration":     0,
+		"days":         r.Days,
+		"note":         r.Note,
+		"reminderType": r.ReminderType,
+		"date":         r.Date,
+		"tags":         tags,
+	}
+	if r.ReminderType == "" {
+		prefs := b.users.Preferences(chatID)
+		data["reminderType"] = prefs.DefaultType()
+	}
+	if r.Title != "" && b.storage.IsTitleExists(chatID, r.Title) {
+		b.sendReplyMessage(chatID, i18n.T(b.lang(chatID), "title.exists_named", bold(r.Title)))
+		data["title"] = ""
+	}
+	if len(r.Times) > 1 {
+		b.sendReplyMessage(chatID, i18n.T(b.lang(chatID), "draft.many_times", esc(strings.Join(r.Times, ", "))))
+	}
+
+	b.promptDraft(chatID, from, UserState{Data: data})
+}
+
+// promptDraft asks for the first missing field of the draft, or shows the
+// complete draft with save and edit buttons.
+func (b *Bot) promptDraft(chatID int64, from *tgbotapi.User, state UserState) {
+	lang := b.lang(chatID)
+	data := state.Data
+	switch {
+	case data["title"].(string) == "":
+		b.askDraftField(chatID, from, state, "title")
+	case data["time"].(string) == "":
+		b.askDraftField(chatID, from, state, "time")
+	case len(data["days"].([]string)) == 0:
+		b.askDraftField(chatID, from, state, "days")
+	case !b.checkDraftDate(chatID, from, state):
+		return
+	default:
+		state.Action = "draft_confirm"
+		b.userState[stateKey{chatID, from.ID}] = state
+		b.sendMessageWithKeyboard(chatID, renderDraft(lang, data)+"\n\n"+i18n.T(lang, "draft.confirm"), getDraftConfirmKeyboard(lang))
+	}
+}
+
+// checkDraftDate makes sure a "once" draft for a given date fires on that
+// date. Schedules only store days and fire on the next one, so a time that
+// already passed today, or a day more than a week ahead, would fire on
+// another date. It asks for the time or days again and reports false then.
+func (b *Bot) checkDraftDate(chatID int64, from *tgbotapi.User, state UserState) bool {
+	date, _ := state.Data["date"].(time.Time)
+	if date.IsZero() || state.Data["reminderType"] != storage.ReminderOnce {
+		return true
+	}
+
+	draft := &storage.Schedule{
+		Time:         state.Data["time"].(string),
+		Days:         state.Data["days"].([]string),
+		ReminderType: storage.ReminderOnce,
+	}
+	now := time.Now().In(b.loc(chatID))
+	next, ok := draft.NextOccurrence(now)
+	if ok && next.Format(storage.DateLayout) == date.Format(storage.DateLayout) {
+		return true
+	}
+
+	lang := b.lang(chatID)
+	if date.Format(storage.DateLayout) == now.Format(storage.DateLayout) {
+		b.sendReplyMessage(chatID, i18n.T(lang, "draft.past", code(draft.Time)))
+		b.askDraftField(chatID, from, state, "time")
+		return false
+	}
+	b.sendReplyMessage(chatID, i18n.T(lang, "draft.too_far", esc(i18n.FormatDate(lang, date))))
+	b.askDraftField(chatID, from, state, "days")
+	return false
+}
+
+func (b *Bot) askDraftField(chatID int64, from *tgbotapi.User, state UserState, field string) {
+	state.Action = "draft_value"
+	state.Data["field"] = field
+	b.userState[stateKey{chatID, from.ID}] = state
+
+	lang := b.lang(chatID)
+	switch field {
+	case "title":
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.title"), getSkipKeyboard(lang))
+	case "time":
+		b.askTime(chatID, i18n.T(lang, "draft.ask_time"))
+	case "days":
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "draft.ask_days"), getSkipKeyboard(lang))
+	case "duration":
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.duration"), getDurationKeyboard(lang))
+	case "note":
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.note"), getNoteKeyboard(lang))
+	case "reminderType":
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.reminder_type"), getReminderTypeKeyboard(lang))
+	}
+}
+
+func (b *Bot) handleDraft(chatID int64, from *tgbotapi.User, state UserState, text string) {
+	lang := b.lang(chatID)
+	k := stateKey{chatID, from.ID}
+	switch state.Action {
+	case "draft_confirm":
+		switch {
+		case i18n.Match(text, "button.save"):
+			if b.storage.IsTitleExists(chatID, state.Data["title"].(string)) {
+				b.sendReplyMessage(chatID, i18n.T(lang, "title.exists"))
+				b.askDraftField(chatID, from, state, "title")
+				return
+			}
+			// The time may have passed while the draft waited
+			if !b.checkDraftDate(chatID, from, state) {
+				return
+			}
+			b.createSchedule(chatID, from, state.Data)
+			delete(b.userState, k)
+		case i18n.Match(text, "button.edit"):
+			state.Action = "draft_field"
+			b.userState[k] = state
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getDraftFieldKeyboard(lang))
+		default:
+			b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "draft.confirm"), getDraftConfirmKeyboard(lang))
+		}
+
+	case "draft_field":
+		fieldButtons := map[string]string{
+			"title": "button.field.title", "time": "button.field.time", "days": "button.field.days",
+			"note": "button.field.note", "reminderType": "button.field.type", "duration": "button.field.duration",
+		}
+		field := ""
+		for f, button := range fieldButtons {
+			if i18n.Match(text, button) {
+				field = f
+				break
+			}
+		}
+		if field == "" {
+			b.sendReplyMessage(chatID, i18n.T(lang, "field.invalid"))
+			b.sendMessageWithKeyboard(chatID, i18n.T(lang, "ask.field"), getDraftFieldKeyboard(lang))
+			return
+		}
+		b.askDraftField(chatID, from, state, field)
+
+	case "draft_value":
+		field := state.Data["field"].(string)
+		switch field {
+		case "title":
+			if b.storage.IsTitleExists(chatID, text) {
+				b.sendReplyMessage(chatID, i18n.T(lang, "title.exists"))
+				return
+			}
+			state.Data["title"] = text
+		case "time":
+			clock, ok := nlp.ParseTime(text)
+			if !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "time.invalid"))
+				return
+			}
+			if duration, _ := state.Data["duration"].(int); !fitsInDay(clock, duration) {
+				b.sendReplyMessage(chatID, i18n.T(lang, "duration.past_midnight", esc(i18n.Duration(lang, time.Duration(duration)*time.Minute))))
+				return
+			}
+			state.Data["time"] = clock
+		case "duration":
+			duration, ok := parseDurationInput(text, state.Data["time"].(string))
+			if !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "duration.invalid"))
+				return
+			}
+			state.Data["duration"] = duration
+		case "days":
+			days := nlp.Parse(text, time.Now().In(b.loc(chatID))).Days
+			if len(days) == 0 {
+				days = parsedays(text)
+			}
+			if len(days) == 0 {
+				b.sendReplyMessage(chatID, i18n.T(lang, "days.invalid_hint"))
+				return
+			}
+			state.Data["days"] = days
+			state.Data["date"] = time.Time{}
+		case "note":
+			if text == "-" || i18n.Match(text, "button.no_note") {
+				text = ""
+			}
+			state.Data["note"] = text
+		case "reminderType":
+			switch {
+			case i18n.Match(text, "button.once"):
+				state.Data["reminderType"] = storage.ReminderOnce
+			case i18n.Match(text, "button.recurring"):
+				state.Data["reminderType"] = storage.ReminderRecurring
+				state.Data["date"] = time.Time{}
+			default:
+				b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+				return
+			}
+		}
+		b.promptDraft(chatID, from, state)
+	}
+}
+
+func getDraftConfirmKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	return tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.save")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.edit")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
+
+func getDraftFieldKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	return tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.title")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.time")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.days")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.note")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.type")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.field.duration")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
+	"turschedule/internal/storage"
+)
+
+// Words separating the title from the resume date in /pause, as in
+// "/pause Rapat Tim sampai 2 November".
+var pauseUntilWords = []string{" sampai ", " hingga ", " until "}
+
+// pauseSchedule handles /pause <judul> [sampai <tanggal>].
+func (b *Bot) pauseSchedule(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	text := strings.Join(args, " ")
+	if text == "" {
+		b.sendMessage(chatID, i18n.T(lang, "pause.usage"))
+		return
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	title, rawDate := text, ""
+	for _, word := range pauseUntilWords {
+		if i := strings.LastIndex(strings.ToLower(text), word); i > 0 {
+			title, rawDate = text[:i], text[i+len(word):]
+			break
+		}
+	}
+	var until time.Time
+	if rawDate != "" {
+		date, ok := nlp.ParseDate(rawDate, now)
+		if !ok {
+			b.sendMessage(chatID, i18n.T(lang, "pause.bad_date"))
+			return
+		}
+		if !date.After(now) {
+			b.sendMessage(chatID, i18n.T(lang, "pause.past_date"))
+			return
+		}
+		until = date
+	}
+
+	schedule, ok := b.findForChange(chatID, from, title)
+	if !ok {
+		return
+	}
+	updated := *schedule
+	updated.Pause(until)
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	if until.IsZero() {
+		b.sendMessage(chatID, i18n.T(lang, "pause.done", bold(schedule.Title), esc(schedule.Title)))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, "pause.until", bold(schedule.Title), esc(i18n.FormatWeekdayDate(lang, until))))
+}
+
+// resumeSchedule handles /resume <judul>, which also brings back skipped
+// occurrences.
+func (b *Bot) resumeSchedule(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "resume.usage"))
+		return
+	}
+
+	schedule, ok := b.findForChange(chatID, from, strings.Join(args, " "))
+	if !ok {
+		return
+	}
+	if !schedule.Paused && len(schedule.SkipDates) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "resume.not_paused", bold(schedule.Title)))
+		return
+	}
+	updated := *schedule
+	updated.Resume()
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	text := i18n.T(lang, "resume.done", bold(schedule.Title))
+	now := time.Now().In(b.loc(chatID))
+	if at, ok := updated.NextOccurrence(now); ok {
+		text += "\n" + i18n.T(lang, "resume.next", renderWhen(lang, at, now))
+	}
+	b.sendMessage(chatID, text)
+}
+
+// skipNext handles /skip <judul> [tanggal]: only the next occurrence, or
+// the one on the given date, is left out.
+func (b *Bot) skipNext(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "skip.usage"))
+		return
+	}
+
+	// The title is the longest prefix naming a schedule, so both
+	// "/skip Rapat Tim" and "/skip Rapat Tim 25 Desember" work
+	var (
+		schedule *storage.Schedule
+		rawDate  string
+	)
+	for i := len(args); i > 0 && schedule == nil; i-- {
+		if s, err := b.storage.GetScheduleByTitle(chatID, strings.Join(args[:i], " ")); err == nil {
+			schedule, rawDate = s, strings.Join(args[i:], " ")
+		}
+	}
+	if schedule == nil {
+		b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+		return
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+	if schedule.ReminderType == storage.ReminderOnce {
+		b.sendMessage(chatID, i18n.T(lang, "skip.once"))
+		return
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	var at time.Time
+	if rawDate == "" {
+		next, ok := schedule.NextOccurrence(now)
+		if !ok {
+			b.sendMessage(chatID, i18n.T(lang, "skip.none", bold(schedule.Title)))
+			return
+		}
+		at = next
+	} else {
+		date, ok := nlp.ParseDate(rawDate, now)
+		if !ok {
+			b.sendMessage(chatID, i18n.T(lang, "pause.bad_date"))
+			return
+		}
+		hour, minute, _ := storage.ParseClock(schedule.Time)
+		at = time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+		if !at.After(now) {
+			b.sendMessage(chatID, i18n.T(lang, "skip.past_date"))
+			return
+		}
+		if !contains(schedule.Days, at.Weekday().String()) {
+			b.sendMessage(chatID, i18n.T(lang, "skip.not_scheduled", bold(schedule.Title), esc(i18n.FormatWeekdayDate(lang, at))))
+			return
+		}
+	}
+	updated := *schedule
+	updated.SkipDates = append([]string(nil), schedule.SkipDates...)
+	updated.Skip(at, now)
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	text := i18n.T(lang, "skip.done", bold(schedule.Title), renderWhen(lang, at, now))
+	if next, ok := updated.NextOccurrence(now); ok {
+		text += "\n" + i18n.T(lang, "resume.next", renderWhen(lang, next, now))
+	}
+	b.sendMessage(chatID, text)
+}
+
+// findForChange looks up the schedule of chatID titled title for a command
+// that changes it, replying when it does not exist or from may not change
+// it.
+func (b *Bot) findForChange(chatID int64, from *tgbotapi.User, title string) (*storage.Schedule, bool) {
+	schedule, err := b.storage.GetScheduleByTitle(chatID, strings.TrimSpace(title))
+	if err != nil {
+		b.sendMessage(chatID, i18n.T(b.lang(chatID), "schedule.notfound"))
+		return nil, false
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return nil, false
+	}
+	return schedule, true
+}
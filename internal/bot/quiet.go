@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// quietUntil reports whether now falls in the quiet hours of chatID and, if
+// so, when they end.
+func (b *Bot) quietUntil(chatID int64, now time.Time) (time.Time, bool) {
+	hours := b.users.Preferences(chatID).QuietHours
+	if hours == "" {
+		return time.Time{}, false
+	}
+	slot, err := storage.ParseQuietHours(hours)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	now = now.In(b.loc(chatID))
+	minute := now.Hour()*60 + now.Minute()
+	if !slot.Contains(minute) {
+		return time.Time{}, false
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), slot.End/60, slot.End%60, 0, 0, now.Location())
+	if slot.End <= minute {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, true
+}
+
+// remind sends the reminder minutes before the occurrence of schedule at
+// eventAt to each recipient. In a recipient's quiet hours the schedule's
+// QuietMode decides whether it is sent silently, held until they end or
+// dropped.
+func (b *Bot) remind(schedule *storage.Schedule, minutes int, eventAt time.Time, buttons func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup) {
+	now := time.Now()
+	for _, chatID := range b.recipients(schedule) {
+		until, quiet := b.quietUntil(chatID, now)
+		switch {
+		case !quiet:
+			b.sendNotification(chatID, renderReminder(b.lang(chatID), schedule, minutes), buttons, false)
+		case schedule.QuietAction() == storage.QuietSkip:
+			log.Printf("Reminder %s untuk %d dilewati karena jam tenang\n", schedule.ID, chatID)
+		case schedule.QuietAction() == storage.QuietDelay:
+			b.delayReminder(schedule.ID, chatID, eventAt, until, buttons)
+		default:
+			b.sendNotification(chatID, renderReminder(b.lang(chatID), schedule, minutes), buttons, true)
+		}
+	}
+}
+
+// delayReminder sends the reminder of the occurrence at eventAt to chatID
+// once quiet hours end at until, counting down from then. It is dropped when
+// the event starts first. Held reminders are kept in memory only, so a
+// restart loses them.
+func (b *Bot) delayReminder(scheduleID string, chatID int64, eventAt, until time.Time, buttons func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup) {
+	if !until.Before(eventAt) {
+		log.Printf("Reminder %s untuk %d dilewati: jam tenang berakhir setelah acara mulai\n", scheduleID, chatID)
+		return
+	}
+
+	key := fmt.Sprintf("%s_%d_%s", scheduleID, chatID, eventAt.Format(storage.DateLayout))
+	b.jobsMu.Lock()
+	pending := b.delayed[key]
+	b.delayed[key] = true
+	b.jobsMu.Unlock()
+	if pending {
+		return
+	}
+
+	time.AfterFunc(time.Until(until), func() {
+		b.jobsMu.Lock()
+		delete(b.delayed, key)
+		b.jobsMu.Unlock()
+
+		// The schedule may have changed or been paused while held
+		schedule, err := b.storage.GetSchedule(scheduleID)
+		if err != nil || !b.firesOn(schedule, eventAt) {
+			return
+		}
+		minutes := int(time.Until(eventAt).Round(time.Minute) / time.Minute)
+		if minutes <= 0 {
+			return
+		}
+		for _, recipient := range b.recipients(schedule) {
+			if recipient == chatID {
+				b.sendNotification(chatID, renderReminder(b.lang(chatID), schedule, minutes), buttons, false)
+				return
+			}
+		}
+	})
+}
+
+// sendNotification sends a reminder with its optional inline keyboard,
+// without sound when silent is set.
+func (b *Bot) sendNotification(chatID int64, text string, buttons func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup, silent bool) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableNotification = silent
+	if buttons != nil {
+		msg.ReplyMarkup = buttons(b.lang(chatID))
+	} else {
+		msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+	}
+	b.send(msg)
+}
+
+// setQuietMode handles /quiet <judul> <silent|delay|skip>, choosing what a
+// schedule's reminders do in quiet hours. Without arguments it shows the
+// quiet hours of the chat.
+func (b *Bot) setQuietMode(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) < 2 {
+		hours := i18n.T(lang, "quiet.off")
+		if slot, err := storage.ParseQuietHours(b.users.Preferences(chatID).QuietHours); err == nil {
+			hours = code(renderSlot(slot))
+		}
+		b.sendMessage(chatID, i18n.T(lang, "quiet.usage", hours))
+		return
+	}
+
+	mode, ok := parseQuietMode(args[len(args)-1])
+	if !ok {
+		b.sendMessage(chatID, i18n.T(lang, "quiet.usage_mode"))
+		return
+	}
+	schedule, ok := b.findForChange(chatID, from, strings.Join(args[:len(args)-1], " "))
+	if !ok {
+		return
+	}
+
+	updated := *schedule
+	updated.QuietMode = mode
+	if mode == storage.QuietSilent {
+		updated.QuietMode = ""
+	}
+	if err := b.storage.UpdateSchedule(&updated); err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, i18n.T(lang, "quiet.saved", bold(schedule.Title), renderQuietMode(lang, mode)))
+}
+
+// parseQuietMode accepts a mode name or one of the quiet mode buttons.
+func parseQuietMode(text string) (string, bool) {
+	for _, mode := range storage.QuietModes {
+		if text == mode || i18n.Match(text, "button.quiet."+mode) {
+			return mode, true
+		}
+	}
+	return "", false
+}
+
+func getQuietModeKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	var row []tgbotapi.KeyboardButton
+	for _, mode := range storage.QuietModes {
+		row = append(row, tgbotapi.NewKeyboardButton(i18n.T(lang, "button.quiet."+mode)))
+	}
+	return tgbotapi.NewReplyKeyboard(
+		row,
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
@@ -0,0 +1,315 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"turschedule/internal/i18n"
+	"turschedule/internal/ical"
+	"turschedule/internal/storage"
+)
+
+// Every message is sent with Telegram's HTML parse mode. Catalog messages
+// carry the markup; anything typed by users, such as titles and notes, must
+// go through esc or one of the helpers below before it is interpolated, or
+// a single "<" makes Telegram reject the whole message.
+
+func esc(s string) string {
+	return html.EscapeString(s)
+}
+
+// bold renders a schedule title.
+func bold(s string) string {
+	return "<b>" + esc(s) + "</b>"
+}
+
+// code renders clock times and other literal values in monospace.
+func code(s string) string {
+	return "<code>" + esc(s) + "</code>"
+}
+
+func italic(s string) string {
+	return "<i>" + esc(s) + "</i>"
+}
+
+// renderNote returns the escaped note, or a placeholder when it is empty.
+func renderNote(lang i18n.Lang, note string) string {
+	if note == "" {
+		return italic(i18n.T(lang, "note.none"))
+	}
+	return esc(note)
+}
+
+// renderTags renders tags as "#kuliah #kerja".
+func renderTags(tags []string) string {
+	return "#" + strings.Join(tags, " #")
+}
+
+func renderType(lang i18n.Lang, reminderType string) string {
+	if reminderType == storage.ReminderOnce {
+		return i18n.T(lang, "type.once")
+	}
+	return i18n.T(lang, "type.recurring")
+}
+
+func renderMainNotification(lang i18n.Lang, s *storage.Schedule) string {
+	return i18n.T(lang, "notify.main", bold(s.Title), code(s.Time), renderNote(lang, s.Note))
+}
+
+func renderReminder(lang i18n.Lang, s *storage.Schedule, minutes int) string {
+	return i18n.N(lang, "notify.reminder", minutes, minutes, bold(s.Title), renderNote(lang, s.Note), code(s.Time))
+}
+
+func renderCreated(lang i18n.Lang, s *storage.Schedule) string {
+	return i18n.T(lang, "schedule.created", bold(s.Title), renderType(lang, s.ReminderType))
+}
+
+// renderListItem renders the n-th entry of /list with its next occurrence.
+func renderListItem(lang i18n.Lang, n int, s *storage.Schedule, holidays *storage.HolidayCalendars, now time.Time) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("\n%d. 📌 %s\n", n, bold(s.Title)))
+	text.WriteString(fmt.Sprintf("   ⏰ %s • 📆 %s\n", renderTimeRange(s), esc(i18n.DayNames(lang, s.Days))))
+	if s.Note != "" {
+		text.WriteString("   📝 " + esc(s.Note) + "\n")
+	}
+	if len(s.Tags) > 0 {
+		text.WriteString("   🏷️ " + esc(renderTags(s.Tags)) + "\n")
+	}
+	if !isPrivate(s.UserID) && s.CreatorName != "" {
+		text.WriteString("   " + i18n.T(lang, "list.created_by", esc(s.CreatorName)) + "\n")
+	}
+	if n := len(s.Subscribers); n > 0 {
+		text.WriteString("   " + i18n.N(lang, "list.subscribers", n, n) + "\n")
+	}
+	text.WriteString(renderStatus(lang, s, holidays, now))
+	return text.String()
+}
+
+// renderSharedItem renders the n-th entry of /shared as seen by the
+// subscriber chatID.
+func renderSharedItem(lang i18n.Lang, n int, s *storage.Schedule, chatID int64, holidays *storage.HolidayCalendars, now time.Time) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("\n%d. 📌 %s\n", n, bold(s.Title)))
+	text.WriteString(fmt.Sprintf("   ⏰ %s • 📆 %s\n", renderTimeRange(s), esc(i18n.DayNames(lang, s.Days))))
+	if len(s.Tags) > 0 {
+		text.WriteString("   🏷️ " + esc(renderTags(s.Tags)) + "\n")
+	}
+	if s.CreatorName != "" {
+		text.WriteString("   " + i18n.T(lang, "shared.from", esc(s.CreatorName)) + "\n")
+	}
+	switch {
+	case s.IsMuted(chatID):
+		text.WriteString("   " + i18n.T(lang, "shared.muted") + "\n")
+	default:
+		text.WriteString(renderStatus(lang, s, holidays, now))
+	}
+	return text.String()
+}
+
+// renderStatus renders the pause state of s or its next occurrence, followed
+// by the dates it skips, as the last lines of a list entry.
+func renderStatus(lang i18n.Lang, s *storage.Schedule, holidays *storage.HolidayCalendars, now time.Time) string {
+	var text strings.Builder
+	if s.IsPausedOn(now.Format(storage.DateLayout)) {
+		if until, err := time.ParseInLocation(storage.DateLayout, s.PausedUntil, now.Location()); err == nil {
+			text.WriteString("   " + i18n.T(lang, "list.paused_until", esc(i18n.FormatWeekdayDate(lang, until))) + "\n")
+		} else {
+			text.WriteString("   " + i18n.T(lang, "list.paused") + "\n")
+		}
+	} else if at, ok := s.NextOccurrence(now); ok {
+		text.WriteString("   ⏭️ " + renderWhen(lang, at, now))
+		if name, ok := holidays.Holiday(s, at); ok {
+			text.WriteString(" • " + italic(i18n.T(lang, "holiday.skipped", name)))
+		}
+		text.WriteString("\n")
+	}
+
+	var skipped []string
+	for _, date := range s.SkipDates {
+		if day, err := time.ParseInLocation(storage.DateLayout, date, now.Location()); err == nil && date >= now.Format(storage.DateLayout) {
+			skipped = append(skipped, i18n.FormatWeekdayDate(lang, day))
+		}
+	}
+	if len(skipped) > 0 {
+		text.WriteString("   " + i18n.T(lang, "list.skipped", esc(strings.Join(skipped, ", "))) + "\n")
+	}
+	if len(s.Holidays) > 0 {
+		text.WriteString("   " + i18n.T(lang, "list.holidays", esc(strings.Join(s.Holidays, ", "))) + "\n")
+	}
+	if s.IsPriority() {
+		text.WriteString("   " + i18n.T(lang, "list.priority", s.NagEvery, s.NagLimit()) + "\n")
+	}
+	if s.QuietMode != "" {
+		text.WriteString("   " + i18n.T(lang, "list.quiet", renderQuietMode(lang, s.QuietMode)) + "\n")
+	}
+	return text.String()
+}
+
+// renderQuietMode names what a schedule's reminders do in quiet hours.
+func renderQuietMode(lang i18n.Lang, mode string) string {
+	if mode == "" {
+		mode = storage.QuietSilent
+	}
+	return i18n.T(lang, "quiet.mode."+mode)
+}
+
+// renderWhen renders an occurrence as its date, time and countdown.
+func renderWhen(lang i18n.Lang, at, now time.Time) string {
+	return fmt.Sprintf("%s %s (%s)", esc(i18n.FormatWeekdayDate(lang, at)), code(at.Format("15:04")), countdown(lang, at.Sub(now)))
+}
+
+// renderInvite describes a schedule offered through an invite.
+func renderInvite(lang i18n.Lang, s *storage.Schedule) string {
+	text := i18n.T(lang, "share.invite", bold(s.Title), renderTimeRange(s), esc(i18n.DayNames(lang, s.Days)))
+	if s.Note != "" {
+		text += "\n📝 " + esc(s.Note)
+	}
+	return text + "\n\n" + i18n.T(lang, "share.invite_hint")
+}
+
+// renderRSVP lists who answered for the occurrence of s on date, which
+// falls on day.
+func renderRSVP(lang i18n.Lang, s *storage.Schedule, date string, day time.Time) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "rsvp.header", bold(s.Title), esc(i18n.FormatWeekdayDate(lang, day)), code(s.Time)))
+	for _, answer := range storage.RSVPAnswers {
+		var names []string
+		for _, r := range s.RSVPs[date] {
+			if r.Answer == answer {
+				names = append(names, esc(r.Name))
+			}
+		}
+		line := "-"
+		if len(names) > 0 {
+			line = strings.Join(names, ", ")
+		}
+		text.WriteString(fmt.Sprintf("\n%s (%d): %s", i18n.T(lang, "rsvp."+answer), len(names), line))
+	}
+	return text.String()
+}
+
+// renderTimeRange renders the start of s, with its end when it has a
+// duration, e.g. "09:00–10:30".
+func renderTimeRange(s *storage.Schedule) string {
+	if end := s.EndTime(); end != "" {
+		return code(s.Time + "–" + end)
+	}
+	return code(s.Time)
+}
+
+// renderSlot renders a span of the day such as "08:00–17:00".
+func renderSlot(slot storage.Slot) string {
+	return storage.FormatClock(slot.Start) + "–" + storage.FormatClock(slot.End)
+}
+
+// renderConflicts warns that s overlaps the conflicts on the days they
+// share.
+func renderConflicts(lang i18n.Lang, s *storage.Schedule, conflicts []*storage.Schedule) string {
+	var text strings.Builder
+	text.WriteString(i18n.N(lang, "conflict.header", len(conflicts), bold(s.Title), len(conflicts)))
+	for _, c := range conflicts {
+		var days []string
+		for _, day := range c.Days {
+			if contains(s.Days, day) {
+				days = append(days, day)
+			}
+		}
+		text.WriteString(fmt.Sprintf("\n• %s %s • %s", bold(c.Title), renderTimeRange(c), esc(i18n.DayNames(lang, days))))
+	}
+	return text.String()
+}
+
+// renderImportItem renders the n-th schedule found in an uploaded calendar.
+func renderImportItem(lang i18n.Lang, n int, s *storage.Schedule) string {
+	return fmt.Sprintf("%d. 📌 %s\n   ⏰ %s • 📆 %s • %s\n", n, bold(s.Title), renderTimeRange(s), esc(i18n.DayNames(lang, s.Days)), renderType(lang, s.ReminderType))
+}
+
+// renderTemplateItem renders the n-th entry of /template.
+func renderTemplateItem(lang i18n.Lang, n int, t storage.Template) string {
+	s := t.Schedule(t.Title)
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("\n%d. 📋 %s\n", n, bold(t.Title)))
+	text.WriteString(fmt.Sprintf("   ⏰ %s • 📆 %s • %s\n", renderTimeRange(s), esc(i18n.DayNames(lang, s.Days)), renderType(lang, s.ReminderType)))
+	if s.Note != "" {
+		text.WriteString("   📝 " + esc(s.Note) + "\n")
+	}
+	if len(s.Tags) > 0 {
+		text.WriteString("   🏷️ " + esc(renderTags(s.Tags)) + "\n")
+	}
+	return text.String()
+}
+
+// renderSkipped lists the events an import left out and why. Titles and
+// values are quoted from the uploaded file.
+func renderSkipped(lang i18n.Lang, skipped []*ical.SkipError) string {
+	var text strings.Builder
+	for _, e := range skipped {
+		key := "import.skip." + string(e.Reason)
+		reason := i18n.T(lang, key)
+		if e.Value != "" {
+			reason = i18n.T(lang, key, esc(e.Value))
+		}
+		text.WriteString("⚠️ " + bold(e.Title) + ": " + reason + "\n")
+	}
+	return text.String()
+}
+
+// renderDraft describes how the bot understood a free-text schedule.
+func renderDraft(lang i18n.Lang, data map[string]interface{}) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "draft.header"))
+	text.WriteString(i18n.T(lang, "label.title", bold(data["title"].(string))))
+	text.WriteString(i18n.T(lang, "label.time", code(data["time"].(string))))
+	if duration, _ := data["duration"].(int); duration > 0 {
+		text.WriteString(i18n.T(lang, "label.duration", esc(i18n.Duration(lang, time.Duration(duration)*time.Minute))))
+	}
+
+	days := i18n.DayNames(lang, data["days"].([]string))
+	if date, ok := data["date"].(time.Time); ok && !date.IsZero() {
+		days += " (" + i18n.FormatDate(lang, date) + ")"
+	}
+	text.WriteString(i18n.T(lang, "label.days", esc(days)))
+
+	if note := data["note"].(string); note != "" {
+		text.WriteString(i18n.T(lang, "label.note", esc(note)))
+	}
+	if tags, _ := data["tags"].([]string); len(tags) > 0 {
+		text.WriteString(i18n.T(lang, "label.tags", esc(renderTags(tags))))
+	}
+
+	typeStr := i18n.T(lang, "type.recurring_weekly")
+	if data["reminderType"] == storage.ReminderOnce {
+		typeStr = i18n.T(lang, "type.once")
+	}
+	text.WriteString(i18n.T(lang, "label.reminder", typeStr))
+	return text.String()
+}
+
+// renderOccurrences lists occurrences with their countdown relative to now,
+// grouped under a date heading per day when withDates is set.
+func renderOccurrences(lang i18n.Lang, occurrences []occurrence, now time.Time, withDates bool) string {
+	var text strings.Builder
+	if !withDates {
+		text.WriteString("\n")
+	}
+	var lastDay string
+	for _, o := range occurrences {
+		if day := o.At.Format("2006-01-02"); withDates && day != lastDay {
+			text.WriteString("\n🗓️ <b>" + esc(i18n.FormatWeekdayDate(lang, o.At)) + "</b>\n")
+			lastDay = day
+		}
+		text.WriteString(fmt.Sprintf("⏰ %s • %s (%s)", code(o.At.Format("15:04")), bold(o.Schedule.Title), countdown(lang, o.At.Sub(now))))
+		if o.Holiday != "" {
+			text.WriteString(" • " + italic(i18n.T(lang, "holiday.skipped", o.Holiday)))
+		}
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// renderError reports err, whose text may quote user input.
+func renderError(lang i18n.Lang, err error) string {
+	return i18n.T(lang, "error", esc(err.Error()))
+}
@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// isShared reports whether more than one person gets the reminders of s,
+// because it belongs to a group or has subscribers.
+func isShared(s *storage.Schedule) bool {
+	return !isPrivate(s.UserID) || len(s.Subscribers) > 0
+}
+
+// rsvpButtons builds the attendance buttons for the occurrence of s on
+// date, or returns nil when s is not shared with anyone.
+func rsvpButtons(s *storage.Schedule, date string) func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	if !isShared(s) {
+		return nil
+	}
+	return func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+		return getRSVPKeyboard(lang, s, date)
+	}
+}
+
+// earliestReminder returns the reminder offset sent first, which carries
+// the attendance buttons before the event.
+func earliestReminder(reminderTimes []int) int {
+	earliest := 0
+	for _, minutes := range reminderTimes {
+		if minutes > earliest {
+			earliest = minutes
+		}
+	}
+	return earliest
+}
+
+// handleRSVPCallback records an answer sent as "rsvp:<answer>:<date>:<id>"
+// and refreshes the counts on the buttons of message, keeping its
+// "Selesai" button.
+func (b *Bot) handleRSVPCallback(chatID int64, from *tgbotapi.User, message *tgbotapi.Message, value string) {
+	messageID := message.MessageID
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return
+	}
+	answer, date, id := parts[0], parts[1], parts[2]
+	lang := b.lang(chatID)
+
+	schedule, err := b.storage.GetSchedule(id)
+	if err != nil || (schedule.UserID != chatID && !schedule.IsSubscriber(chatID)) {
+		b.editMessageKeyboard(chatID, messageID, nil)
+		return
+	}
+
+	rsvp := storage.RSVP{UserID: from.ID, Name: displayName(from), Answer: answer}
+	if err := b.storage.SetRSVP(id, date, rsvp); err != nil {
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+
+	keyboard := getRSVPKeyboard(lang, schedule, date)
+	if message.ReplyMarkup != nil {
+		for _, row := range message.ReplyMarkup.InlineKeyboard {
+			if len(row) > 0 && row[0].CallbackData != nil && strings.HasPrefix(*row[0].CallbackData, "done:") {
+				keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, row)
+			}
+		}
+	}
+	b.editMessageKeyboard(chatID, messageID, &keyboard)
+}
+
+// sendRSVP handles /rsvp <judul> by showing who answered for the upcoming
+// occurrence, or the most recent one that has answers.
+func (b *Bot) sendRSVP(chatID int64, args []string) {
+	lang := b.lang(chatID)
+	title := strings.Join(args, " ")
+	if title == "" {
+		b.sendMessage(chatID, i18n.T(lang, "rsvp.usage"))
+		return
+	}
+
+	schedule, err := b.storage.GetScheduleByTitle(chatID, title)
+	if err != nil {
+		b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+		return
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	date, ok := rsvpDate(schedule, now)
+	if !ok {
+		b.sendMessage(chatID, i18n.T(lang, "rsvp.none", bold(schedule.Title)))
+		return
+	}
+	day, err := time.ParseInLocation(storage.DateLayout, date, now.Location())
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, renderRSVP(lang, schedule, date, day))
+}
+
+// rsvpDate picks the first occurrence from today on that has answers,
+// falling back to the latest past one.
+func rsvpDate(s *storage.Schedule, now time.Time) (string, bool) {
+	if len(s.RSVPs) == 0 {
+		return "", false
+	}
+	dates := make([]string, 0, len(s.RSVPs))
+	for date := range s.RSVPs {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	today := now.Format(storage.DateLayout)
+	for _, date := range dates {
+		if date >= today {
+			return date, true
+		}
+	}
+	return dates[len(dates)-1], true
+}
+
+func getRSVPKeyboard(lang i18n.Lang, s *storage.Schedule, date string) tgbotapi.InlineKeyboardMarkup {
+	counts := make(map[string]int)
+	for _, r := range s.RSVPs[date] {
+		counts[r.Answer]++
+	}
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, answer := range storage.RSVPAnswers {
+		label := i18n.T(lang, "rsvp."+answer)
+		if n := counts[answer]; n > 0 {
+			label += fmt.Sprintf(" (%d)", n)
+		}
+		data := fmt.Sprintf("rsvp:%s:%s:%s", answer, date, s.ID)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, data))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
@@ -0,0 +1,249 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/nlp"
+	"turschedule/internal/storage"
+)
+
+// settingsFields are the rows of the /settings menu, in order.
+var settingsFields = []string{"language", "timezone", "reminders", "type", "quiet", "workhours", "digest"}
+
+// settingsChoices are offered as buttons when asking for a new value.
+var settingsChoices = map[string][]string{
+	"timezone":  {"WIB", "WITA", "WIT"},
+	"reminders": {"60, 30, 5", "30, 10", "15"},
+	"quiet":     {"22:00-06:00", "23:00-07:00"},
+	"workhours": {"08:00-17:00", "09:00-18:00"},
+}
+
+// loc returns the time zone chatID's schedules are evaluated in.
+func (b *Bot) loc(chatID int64) *time.Location {
+	tz := b.users.Preferences(chatID).Timezone
+	if tz == "" {
+		return b.location
+	}
+	if loc, ok := b.zones.Load(tz); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		log.Printf("Zona waktu %s untuk %d tidak dikenal: %v\n", tz, chatID, err)
+		return b.location
+	}
+	b.zones.Store(tz, loc)
+	return loc
+}
+
+// cronSpec pins spec to chatID's own time zone when it chose one, since
+// the scheduler itself runs in TIMEZONE.
+func (b *Bot) cronSpec(chatID int64, spec string) string {
+	if tz := b.users.Preferences(chatID).Timezone; tz != "" {
+		return "CRON_TZ=" + tz + " " + spec
+	}
+	return spec
+}
+
+// sendSettings shows the /settings menu, editing messageID when it is not
+// zero.
+func (b *Bot) sendSettings(chatID int64, messageID int) {
+	lang := b.lang(chatID)
+	prefs := b.users.Preferences(chatID)
+	withDefault := func(value string, isDefault bool) string {
+		if isDefault {
+			return value + " " + i18n.T(lang, "settings.default")
+		}
+		return value
+	}
+
+	var reminders []string
+	for _, minutes := range prefs.DefaultTimes() {
+		reminders = append(reminders, i18n.Duration(lang, time.Duration(minutes)*time.Minute))
+	}
+	quiet := i18n.T(lang, "quiet.off")
+	if prefs.QuietHours != "" {
+		slot, _ := storage.ParseQuietHours(prefs.QuietHours)
+		quiet = code(renderSlot(slot))
+	}
+	daily, weekly := digestStatus(lang, prefs.DigestTime, prefs.WeeklyDigest)
+
+	text := i18n.T(lang, "settings.header",
+		esc(lang.Name()),
+		withDefault(code(b.loc(chatID).String()), prefs.Timezone == ""),
+		withDefault(esc(strings.Join(reminders, ", ")), len(prefs.ReminderTimes) == 0),
+		withDefault(renderType(lang, prefs.DefaultType()), prefs.ReminderType == ""),
+		quiet,
+		withDefault(code(renderSlot(b.workHours(chatID))), prefs.WorkHours == ""),
+		daily, weekly,
+	)
+
+	keyboard := getSettingsKeyboard(lang)
+	if messageID != 0 {
+		b.editMessage(chatID, messageID, text, &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = keyboard
+	b.send(msg)
+}
+
+// handleSettingsCallback handles the buttons of the /settings menu by asking
+// for the new value of the chosen field.
+func (b *Bot) handleSettingsCallback(chatID int64, from *tgbotapi.User, field string) {
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+	lang := b.lang(chatID)
+	k := stateKey{chatID, from.ID}
+
+	switch field {
+	case "language":
+		b.userState[k] = UserState{Action: "language_select", Data: make(map[string]interface{})}
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "language.prompt"), getLanguageKeyboard(lang))
+	case "digest":
+		b.sendDigestSettings(chatID, 0)
+	case "timezone", "reminders", "type", "quiet", "workhours":
+		b.userState[k] = UserState{Action: "settings_value", Data: map[string]interface{}{"field": field}}
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "settings.ask."+field), getSettingsValueKeyboard(lang, field))
+	}
+}
+
+// setPreference stores the answer to settings.ask.<field>. It reports
+// whether the answer was accepted; if not, the reason has been sent.
+func (b *Bot) setPreference(chatID int64, field, text string) bool {
+	lang := b.lang(chatID)
+	prefs := b.users.Preferences(chatID)
+	reset := text == "-" || strings.EqualFold(text, "reset") || i18n.Match(text, "button.default")
+
+	var err error
+	switch field {
+	case "timezone":
+		prefs.Timezone = ""
+		if !reset {
+			prefs.Timezone, err = storage.ParseTimezone(text)
+		}
+	case "reminders":
+		prefs.ReminderTimes = nil
+		if !reset {
+			var ok bool
+			if prefs.ReminderTimes, ok = parseReminderTimes(text); !ok {
+				b.sendReplyMessage(chatID, i18n.T(lang, "settings.reminders_invalid"))
+				return false
+			}
+		}
+	case "type":
+		switch {
+		case reset:
+			prefs.ReminderType = ""
+		case i18n.Match(text, "button.once"):
+			prefs.ReminderType = storage.ReminderOnce
+		case i18n.Match(text, "button.recurring"):
+			prefs.ReminderType = storage.ReminderRecurring
+		default:
+			b.sendReplyMessage(chatID, i18n.T(lang, "choice.invalid"))
+			return false
+		}
+	case "quiet":
+		prefs.QuietHours = ""
+		if !reset {
+			var slot storage.Slot
+			if slot, err = storage.ParseQuietHours(strings.Join(strings.Fields(text), "")); err == nil {
+				prefs.QuietHours = slot.String()
+			}
+		}
+	case "workhours":
+		prefs.WorkHours = ""
+		if !reset {
+			var slot storage.Slot
+			if slot, err = storage.ParseSlot(strings.Join(strings.Fields(text), "")); err == nil {
+				prefs.WorkHours = slot.String()
+			}
+		}
+	}
+	if err != nil {
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return false
+	}
+
+	timezoneChanged := prefs.Timezone != b.users.Preferences(chatID).Timezone
+	if err := b.users.SetPreferences(chatID, prefs); err != nil {
+		log.Printf("Gagal menyimpan pengaturan %d: %v\n", chatID, err)
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return false
+	}
+	if timezoneChanged {
+		b.rescheduleChat(chatID)
+	}
+
+	b.sendMessage(chatID, i18n.T(lang, "settings.saved"))
+	b.sendSettings(chatID, 0)
+	return true
+}
+
+// rescheduleChat rebuilds the cron jobs of every schedule and digest of
+// chatID, for instance after its time zone changed.
+func (b *Bot) rescheduleChat(chatID int64) {
+	for _, schedule := range b.storage.GetUserSchedules(chatID) {
+		b.Reschedule(schedule)
+	}
+	b.scheduleDigest(chatID)
+}
+
+// parseReminderTimes reads a list of reminder offsets such as "60, 30, 5"
+// or "1 jam, 15 menit".
+func parseReminderTimes(text string) ([]int, bool) {
+	var minutes []int
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+		m, ok := nlp.ParseDuration(part, "00:00")
+		if !ok || m > storage.MaxReminderMinutes {
+			return nil, false
+		}
+		minutes = append(minutes, m)
+	}
+	if len(minutes) == 0 {
+		return nil, false
+	}
+	return storage.NormalizeReminderTimes(minutes), true
+}
+
+func getSettingsKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, field := range settingsFields {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "settings.button."+field), "set:"+field))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func getSettingsValueKeyboard(lang i18n.Lang, field string) tgbotapi.ReplyKeyboardMarkup {
+	var row []tgbotapi.KeyboardButton
+	if field == "type" {
+		row = append(row,
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.once")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.recurring")),
+		)
+	}
+	for _, choice := range settingsChoices[field] {
+		row = append(row, tgbotapi.NewKeyboardButton(choice))
+	}
+	return tgbotapi.NewReplyKeyboard(
+		row,
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.default")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+		),
+	)
+}
@@ -0,0 +1,252 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// sharePrefix marks /start payloads that carry an invite token, as in
+// t.me/<bot>?start=share_<token>.
+const sharePrefix = "share_"
+
+// rememberUsername stores the username of users writing to the bot in
+// private, the only ones it can send an invite to.
+func (b *Bot) rememberUsername(chatID int64, from *tgbotapi.User) {
+	if !isPrivate(chatID) || from.UserName == "" {
+		return
+	}
+	if err := b.users.SetUsername(from.ID, from.UserName); err != nil {
+		log.Printf("Gagal menyimpan username %d: %v\n", from.ID, err)
+	}
+}
+
+// shareSchedule handles /share <judul> [@username|reset]: it replies with
+// an invite link, sends the invite straight to a user the bot knows, or
+// replaces the link so the old one stops working.
+func (b *Bot) shareSchedule(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	var username string
+	if len(args) > 1 && strings.HasPrefix(args[len(args)-1], "@") {
+		username = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	title := strings.Join(args, " ")
+	if title == "" {
+		b.sendMessage(chatID, i18n.T(lang, "share.usage"))
+		return
+	}
+
+	schedule, err := b.storage.GetScheduleByTitle(chatID, title)
+	// "reset" is only a keyword when no schedule carries the whole title
+	reset := false
+	if err != nil && username == "" && len(args) > 1 && strings.EqualFold(args[len(args)-1], "reset") {
+		schedule, err = b.storage.GetScheduleByTitle(chatID, strings.Join(args[:len(args)-1], " "))
+		reset = true
+	}
+	if err != nil {
+		b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+		return
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+
+	var token string
+	if reset {
+		token, err = b.storage.ResetShareToken(schedule.ID)
+	} else {
+		token, err = b.storage.ShareToken(schedule.ID)
+	}
+	if err != nil {
+		log.Printf("Gagal membuat token undangan %s: %v\n", schedule.ID, err)
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	link := fmt.Sprintf("https://t.me/%s?start=%s%s", b.api.Self.UserName, sharePrefix, token)
+
+	if username == "" {
+		text := i18n.T(lang, "share.link", bold(schedule.Title), esc(link)) + i18n.T(lang, "share.reset_hint", esc(schedule.Title))
+		if reset {
+			text = i18n.T(lang, "share.reset") + text
+		}
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	recipient, ok := b.users.UserByUsername(username)
+	if !ok {
+		b.sendMessage(chatID, i18n.T(lang, "share.unknown_user", esc(username), esc(link)))
+		return
+	}
+	if recipient == schedule.UserID || schedule.IsSubscriber(recipient) {
+		b.sendMessage(chatID, i18n.T(lang, "share.already_named", esc(username), bold(schedule.Title)))
+		return
+	}
+	b.sendInvite(recipient, schedule, token)
+	b.sendMessage(chatID, i18n.T(lang, "share.sent", bold(schedule.Title), esc(username)))
+}
+
+// openInvite handles an invite link opened with /start share_<token>.
+func (b *Bot) openInvite(chatID int64, token string) {
+	lang := b.lang(chatID)
+	schedule, err := b.storage.GetScheduleByShareToken(token)
+	switch {
+	case err != nil:
+		b.sendMessage(chatID, i18n.T(lang, "share.invalid"))
+	case schedule.UserID == chatID:
+		b.sendMessage(chatID, i18n.T(lang, "share.own"))
+	case schedule.IsSubscriber(chatID):
+		b.sendMessage(chatID, i18n.T(lang, "share.already", bold(schedule.Title)))
+	default:
+		b.sendInvite(chatID, schedule, token)
+	}
+}
+
+// sendInvite shows schedule to chatID with buttons to accept or decline.
+func (b *Bot) sendInvite(chatID int64, schedule *storage.Schedule, token string) {
+	lang := b.lang(chatID)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.accept"), "shr:ok:"+token),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.decline"), "shr:no:"+token),
+	))
+	b.sendMessageWithKeyboard(chatID, renderInvite(lang, schedule), keyboard)
+}
+
+// handleShareCallback handles "shr:<action>:<arg>": accepting or declining
+// an invite by token, and muting or leaving a shared schedule by ID.
+func (b *Bot) handleShareCallback(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	lang := b.lang(chatID)
+	action, arg, _ := strings.Cut(value, ":")
+	// Subscribing, muting and leaving only change what this chat receives:
+	// a private chat decides alone, a group needs one of its admins
+	allowed := func() bool {
+		return isPrivate(chatID) || b.requireAdmin(chatID, from.ID)
+	}
+
+	switch action {
+	case "ok":
+		schedule, err := b.storage.GetScheduleByShareToken(arg)
+		if err != nil {
+			b.editMessage(chatID, messageID, i18n.T(lang, "share.invalid"), nil)
+			return
+		}
+		if !allowed() {
+			return
+		}
+		if err := b.storage.Subscribe(schedule.ID, chatID); err != nil {
+			b.sendReplyMessage(chatID, renderError(lang, err))
+			return
+		}
+		b.editMessage(chatID, messageID, i18n.T(lang, "share.accepted", bold(schedule.Title)), nil)
+		b.sendMessage(schedule.UserID, i18n.T(b.lang(schedule.UserID), "share.joined", esc(displayName(from)), bold(schedule.Title)))
+
+	case "no":
+		b.editMessage(chatID, messageID, i18n.T(lang, "share.declined"), nil)
+
+	case "mute", "leave":
+		schedule, err := b.storage.GetSchedule(arg)
+		if err != nil || !schedule.IsSubscriber(chatID) {
+			b.sendReplyMessage(chatID, i18n.T(lang, "list.gone"))
+		} else if allowed() {
+			if action == "mute" {
+				err = b.storage.SetMuted(arg, chatID, !schedule.IsMuted(chatID))
+			} else {
+				err = b.storage.Unsubscribe(arg, chatID)
+			}
+			if err != nil {
+				b.sendReplyMessage(chatID, renderError(lang, err))
+			}
+		}
+		b.sendShared(chatID, messageID)
+	}
+}
+
+// sendShared lists the schedules chatID subscribed to with buttons to mute
+// or leave each one. A non-zero messageID edits that message in place.
+func (b *Bot) sendShared(chatID int64, messageID int) {
+	lang := b.lang(chatID)
+	schedules := b.storage.GetSubscribedSchedules(chatID)
+	if len(schedules) == 0 {
+		if messageID != 0 {
+			b.editMessage(chatID, messageID, i18n.T(lang, "shared.none"), nil)
+			return
+		}
+		b.sendMessage(chatID, i18n.T(lang, "shared.none"))
+		return
+	}
+
+	now := time.Now().In(b.loc(chatID))
+	sortSchedules(schedules, listSortNext, now)
+
+	var (
+		text strings.Builder
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+	text.WriteString(i18n.T(lang, "shared.header", len(schedules)))
+	for i, s := range schedules {
+		text.WriteString(renderSharedItem(lang, i+1, s, chatID, b.holidays, now))
+
+		mute := fmt.Sprintf("🔕 %d", i+1)
+		if s.IsMuted(chatID) {
+			mute = fmt.Sprintf("🔔 %d", i+1)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mute, "shr:mute:"+s.ID),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🚪 %d", i+1), "shr:leave:"+s.ID),
+		))
+	}
+	text.WriteString("\n" + i18n.T(lang, "shared.legend"))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if messageID != 0 {
+		b.editMessage(chatID, messageID, text.String(), &keyboard)
+		return
+	}
+	b.sendMessageWithKeyboard(chatID, text.String(), keyboard)
+}
+
+// notify sends a message about schedule to its owner and every subscriber
+// that has not muted it or one of its tags, each in their own language.
+// buttons, if not nil, adds an inline keyboard to each message.
+func (b *Bot) notify(schedule *storage.Schedule, render func(lang i18n.Lang) string, buttons func(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup) {
+	for _, chatID := range b.recipients(schedule) {
+		lang := b.lang(chatID)
+		if buttons == nil {
+			b.sendMessage(chatID, render(lang))
+			continue
+		}
+		b.sendMessageWithKeyboard(chatID, render(lang), buttons(lang))
+	}
+}
+
+// recipients lists the owner of schedule and its subscribers, leaving out
+// the chats that muted it or one of its tags.
+func (b *Bot) recipients(schedule *storage.Schedule) []int64 {
+	var chats []int64
+	if !b.isTagMuted(schedule.UserID, schedule) {
+		chats = append(chats, schedule.UserID)
+	}
+	for _, chatID := range schedule.Subscribers {
+		if !schedule.IsMuted(chatID) && !b.isTagMuted(chatID, schedule) {
+			chats = append(chats, chatID)
+		}
+	}
+	return chats
+}
+
+// notifySubscribers tells the subscribers of schedule that its owner
+// changed it. Muted subscribers are left alone.
+func (b *Bot) notifySubscribers(schedule *storage.Schedule) {
+	for _, chatID := range schedule.Subscribers {
+		if schedule.IsMuted(chatID) {
+			continue
+		}
+		b.sendMessage(chatID, i18n.T(b.lang(chatID), "share.updated", bold(schedule.Title)))
+	}
+}
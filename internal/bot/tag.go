@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// splitTagFilter takes a "#tag" filter out of command arguments, as in
+// /list #kuliah or /next 10 #kerja, and returns the remaining arguments.
+func splitTagFilter(args []string) (tag string, rest []string, err error) {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "#") || tag != "" {
+			rest = append(rest, arg)
+			continue
+		}
+		tags, err := storage.ParseTags(arg)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(tags) > 0 {
+			tag = tags[0]
+		}
+	}
+	return tag, rest, nil
+}
+
+// extractHashtags removes "#tag" words from a free-text schedule and returns
+// them as tags. Words that are not valid tags are left in the text.
+func extractHashtags(text string) (string, []string) {
+	var (
+		words []string
+		tags  []string
+	)
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(word, "#") {
+			if parsed, err := storage.ParseTags(word); err == nil && len(parsed) > 0 {
+				tags = append(tags, parsed...)
+				continue
+			}
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), tags
+}
+
+// filterByTag keeps the schedules tagged tag; an empty tag keeps all.
+func filterByTag(schedules []*storage.Schedule, tag string) []*storage.Schedule {
+	if tag == "" {
+		return schedules
+	}
+	var result []*storage.Schedule
+	for _, s := range schedules {
+		if s.HasTag(tag) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// isTagMuted reports whether chatID muted one of the tags of s.
+func (b *Bot) isTagMuted(chatID int64, s *storage.Schedule) bool {
+	for _, tag := range b.users.MutedTags(chatID) {
+		if contains(s.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// muteTag handles /mute #tag and /unmute #tag. Without a tag it lists the
+// muted tags.
+func (b *Bot) muteTag(chatID int64, from *tgbotapi.User, args []string, muted bool) {
+	lang := b.lang(chatID)
+	tags, err := storage.ParseTags(strings.Join(args, " "))
+	if err != nil {
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	if len(tags) == 0 {
+		text := i18n.T(lang, "tag.mute_usage")
+		if current := b.users.MutedTags(chatID); len(current) > 0 {
+			text = i18n.T(lang, "tag.muted_list", esc(renderTags(current))) + "\n\n" + text
+		}
+		b.sendMessage(chatID, text)
+		return
+	}
+	if !b.requireAdmin(chatID, from.ID) {
+		return
+	}
+
+	for _, tag := range tags {
+		if err := b.users.SetTagMuted(chatID, tag, muted); err != nil {
+			log.Printf("Gagal menyimpan tag bisu %d: %v\n", chatID, err)
+			b.sendMessage(chatID, renderError(lang, err))
+			return
+		}
+	}
+	key := "tag.unmuted"
+	if muted {
+		key = "tag.muted"
+	}
+	b.sendMessage(chatID, i18n.T(lang, key, esc(renderTags(tags))))
+}
+
+// getTagsKeyboard offers the tags already used in the chat.
+func getTagsKeyboard(lang i18n.Lang, tags []string) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	var row []tgbotapi.KeyboardButton
+	for _, tag := range tags {
+		row = append(row, tgbotapi.NewKeyboardButton("#"+tag))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton(i18n.T(lang, "button.no_tags")),
+		tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel")),
+	))
+	return tgbotapi.NewReplyKeyboard(rows...)
+}
@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+	"turschedule/internal/storage"
+)
+
+// startDuplicate handles /duplicate <judul> by asking for the title of the
+// copy. Like /add it is open to every member of a group.
+func (b *Bot) startDuplicate(chatID int64, from *tgbotapi.User, args []string) {
+	lang := b.lang(chatID)
+	if len(args) == 0 {
+		b.sendMessage(chatID, i18n.T(lang, "duplicate.usage"))
+		return
+	}
+	schedule, err := b.storage.GetScheduleByTitle(chatID, strings.Join(args, " "))
+	if err != nil {
+		b.sendMessage(chatID, i18n.T(lang, "schedule.notfound"))
+		return
+	}
+
+	b.userState[stateKey{chatID, from.ID}] = UserState{
+		Action: "duplicate_title",
+		Data:   map[string]interface{}{"schedule": schedule},
+	}
+	b.sendMessageWithKeyboard(chatID, i18n.T(lang, "duplicate.ask_title", bold(schedule.Title)), getTitleKeyboard(lang, b.copyTitle(lang, schedule)))
+}
+
+// saveDuplicate stores the copy started by /duplicate under title. A
+// taken title is asked again.
+func (b *Bot) saveDuplicate(chatID int64, from *tgbotapi.User, schedule *storage.Schedule, title string) {
+	lang := b.lang(chatID)
+	title = strings.TrimSpace(title)
+	if title == "" || b.storage.IsTitleExists(chatID, title) {
+		b.sendReplyMessage(chatID, i18n.T(lang, "title.exists"))
+		b.sendMessageWithKeyboard(chatID, i18n.T(lang, "duplicate.ask_title", bold(schedule.Title)), getTitleKeyboard(lang, b.copyTitle(lang, schedule)))
+		return
+	}
+
+	delete(b.userState, stateKey{chatID, from.ID})
+	copied, err := b.duplicateSchedule(schedule, title, from)
+	if err != nil {
+		log.Printf("Gagal menduplikasi jadwal %s: %v\n", schedule.ID, err)
+		b.sendMessage(chatID, renderError(lang, err))
+		return
+	}
+	b.sendMessage(chatID, renderCreated(lang, copied))
+	b.warnConflicts(chatID, copied)
+	b.offerEdit(chatID, from, copied)
+}
+
+// offerEdit lets from adjust a schedule that was just copied, typically its
+// time or days, through the /edit flow. Group members who may not edit
+// schedules are not asked.
+func (b *Bot) offerEdit(chatID int64, from *tgbotapi.User, schedule *storage.Schedule) {
+	if !b.isAdmin(chatID, from.ID) {
+		return
+	}
+	lang := b.lang(chatID)
+	b.userState[stateKey{chatID, from.ID}] = UserState{
+		Action: "edit_continue",
+		Data:   map[string]interface{}{"schedule": schedule},
+	}
+	b.sendMessageWithKeyboard(chatID, i18n.T(lang, "duplicate.ask_edit", bold(schedule.Title)), getEditContinueKeyboard(lang))
+}
+
+// templateCommand handles /template, which lists the templates of the
+// chat, and /template <judul>, which saves a schedule as a template.
+func (b *Bot) templateCommand(chatID int64, from *tgbotapi.User, args []string) {
+	if len(args) == 0 {
+		b.sendTemplates(chatID, 0)
+		return
+	}
+	schedule, ok := b.findForChange(chatID, from, strings.Join(args, " "))
+	if !ok {
+		return
+	}
+
+	lang := b.lang(chatID)
+	b.userState[stateKey{chatID, from.ID}] = UserState{
+		Action: "template_title",
+		Data:   map[string]interface{}{"schedule": schedule},
+	}
+	b.sendMessageWithKeyboard(chatID, i18n.T(lang, "template.ask_title", bold(schedule.Title)),
+		getTitleKeyboard(lang, schedule.Title+" "+storage.TemplateCounter, schedule.Title))
+}
+
+// saveTemplate stores schedule as a template with the title pattern
+// title, replacing the template that has the same pattern.
+func (b *Bot) saveTemplate(chatID int64, from *tgbotapi.User, schedule *storage.Schedule, title string) {
+	lang := b.lang(chatID)
+	template, err := b.users.SaveTemplate(chatID, storage.NewTemplate(schedule, title))
+	if err != nil {
+		b.sendReplyMessage(chatID, renderError(lang, err))
+		return
+	}
+	delete(b.userState, stateKey{chatID, from.ID})
+	b.sendMessage(chatID, i18n.T(lang, "template.saved", bold(template.Title)))
+}
+
+// sendTemplates lists the templates of chatID with buttons to create a
+// schedule from each one or delete it. A non-zero messageID edits that
+// message in place.
+func (b *Bot) sendTemplates(chatID int64, messageID int) {
+	lang := b.lang(chatID)
+	templates := b.users.Templates(chatID)
+	if len(templates) == 0 {
+		if messageID != 0 {
+			b.editMessage(chatID, messageID, i18n.T(lang, "template.none"), nil)
+			return
+		}
+		b.sendMessage(chatID, i18n.T(lang, "template.none"))
+		return
+	}
+
+	var (
+		text strings.Builder
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+	text.WriteString(i18n.T(lang, "template.header", len(templates)))
+	for i, t := range templates {
+		text.WriteString(renderTemplateItem(lang, i+1, t))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("➕ %d", i+1), "tpl:use:"+t.ID),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🗑️ %d", i+1), "tpl:del:"+t.ID),
+		))
+	}
+	text.WriteString("\n" + i18n.T(lang, "template.legend"))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if messageID != 0 {
+		b.editMessage(chatID, messageID, text.String(), &keyboard)
+		return
+	}
+	b.sendMessageWithKeyboard(chatID, text.String(), keyboard)
+}
+
+// handleTemplateCallback handles the /template buttons, sent as
+// "tpl:use:<id>" and "tpl:del:<id>".
+func (b *Bot) handleTemplateCallback(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	action, id, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	lang := b.lang(chatID)
+	template, exists := b.users.Template(chatID, id)
+	if !exists {
+		b.sendReplyMessage(chatID, i18n.T(lang, "template.gone"))
+		b.sendTemplates(chatID, messageID)
+		return
+	}
+
+	switch action {
+	case "use":
+		schedule := template.Schedule(b.templateTitle(chatID, template))
+		schedule.UserID = chatID
+		schedule.CreatedBy = from.ID
+		schedule.CreatorName = displayName(from)
+		if err := b.storage.AddSchedule(schedule); err != nil {
+			b.sendReplyMessage(chatID, renderError(lang, err))
+			return
+		}
+		b.scheduleReminder(schedule)
+		b.sendMessage(chatID, renderCreated(lang, schedule))
+		b.warnConflicts(chatID, schedule)
+		b.offerEdit(chatID, from, schedule)
+
+	case "del":
+		if !b.requireAdmin(chatID, from.ID) {
+			return
+		}
+		if _, err := b.users.DeleteTemplate(chatID, id); err != nil {
+			b.sendReplyMessage(chatID, renderError(lang, err))
+		}
+		b.sendTemplates(chatID, messageID)
+	}
+}
+
+// templateTitle picks an unused title for the next schedule made from t:
+// the lowest free number for a pattern with storage.TemplateCounter,
+// otherwise the title itself followed by a number once it is taken.
+func (b *Bot) templateTitle(chatID int64, t storage.Template) string {
+	if strings.Contains(t.Title, storage.TemplateCounter) {
+		n := 1
+		for b.storage.IsTitleExists(chatID, t.TitleFor(n)) {
+			n++
+		}
+		return t.TitleFor(n)
+	}
+	title := t.Title
+	for n := 2; b.storage.IsTitleExists(chatID, title); n++ {
+		title = fmt.Sprintf("%s %d", t.Title, n)
+	}
+	return title
+}
+
+// getTitleKeyboard suggests titles to pick from instead of typing one.
+func getTitleKeyboard(lang i18n.Lang, titles ...string) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	for _, title := range titles {
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(title)))
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, "button.cancel"))))
+	return tgbotapi.NewReplyKeyboard(rows...)
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"turschedule/internal/i18n"
+)
+
+// askTime prompts for a time. The user can type any minute or pick the hour
+// and a 5-minute step from the inline picker.
+func (b *Bot) askTime(chatID int64, prompt string) {
+	lang := b.lang(chatID)
+	b.sendMessageWithKeyboard(chatID, prompt+"\n"+i18n.T(lang, "time.type_or_pick"), getSkipKeyboard(lang))
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "time.pick_hour"))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = getHourPickerKeyboard()
+	b.send(msg)
+}
+
+// expectsTime reports whether the user's current flow is waiting for a time.
+func (b *Bot) expectsTime(k stateKey) bool {
+	state, exists := b.userState[k]
+	if !exists {
+		return false
+	}
+	switch state.Action {
+	case "add_time", "digest_time":
+		return true
+	case "edit_value", "draft_value":
+		return state.Data["field"] == "time"
+	}
+	return false
+}
+
+// handleTimePicker walks the inline picker from hour to minute and feeds the
+// chosen time into the current flow as if it had been typed.
+func (b *Bot) handleTimePicker(chatID int64, from *tgbotapi.User, messageID int, value string) {
+	lang := b.lang(chatID)
+	if !b.expectsTime(stateKey{chatID, from.ID}) {
+		// In a group the picker may belong to another member's wizard
+		if isPrivate(chatID) {
+			b.editMessage(chatID, messageID, i18n.T(lang, "time.picker_closed"), nil)
+		}
+		return
+	}
+
+	step, arg, _ := strings.Cut(value, ":")
+	switch step {
+	case "h":
+		keyboard := getMinutePickerKeyboard(lang, arg)
+		b.editMessage(chatID, messageID, i18n.T(lang, "time.pick_minute", code(arg)), &keyboard)
+	case "back":
+		keyboard := getHourPickerKeyboard()
+		b.editMessage(chatID, messageID, i18n.T(lang, "time.pick_hour"), &keyboard)
+	case "m":
+		b.editMessage(chatID, messageID, i18n.T(lang, "time.picked", code(arg)), nil)
+		b.handleMessage(chatID, from, arg)
+	}
+}
+
+func getHourPickerKeyboard() tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for start := 0; start < 24; start += 6 {
+		var row []tgbotapi.InlineKeyboardButton
+		for h := start; h < start+6; h++ {
+			hour := fmt.Sprintf("%02d", h)
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(hour, "time:h:"+hour))
+		}
+		rows = append(rows, row)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func getMinutePickerKeyboard(lang i18n.Lang, hour string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for start := 0; start < 60; start += 20 {
+		var row []tgbotapi.InlineKeyboardButton
+		for m := start; m < start+20; m += 5 {
+			clock := fmt.Sprintf("%s:%02d", hour, m)
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(clock, "time:m:"+clock))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.change_hour"), "time:back"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
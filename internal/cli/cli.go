@@ -0,0 +1,236 @@
+// Package cli implements the offline subcommands of the turschedule binary.
+// They work directly on the data file at DB_PATH and never contact Telegram,
+// so stop the bot first when changing data or it will overwrite the file.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"turschedule/config"
+	"turschedule/internal/storage"
+)
+
+const usage = `Penggunaan: turschedule [perintah] [opsi]
+
+Tanpa perintah, bot Telegram dijalankan.
+
+Perintah:
+  validate                      Periksa file data dan laporkan jadwal tidak valid
+  export --user ID --format F   Ekspor jadwal user (F: json, ics, csv)
+  import --file PATH            Impor jadwal dari file json, ics atau csv
+  migrate [--dry-run]           Ubah file data lama ke format terbaru
+  next --user ID [--count N]    Tampilkan jadwal yang akan datang
+  help                          Tampilkan bantuan ini
+`
+
+// Run executes the subcommand in args[0] with the remaining arguments.
+func Run(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	c := &command{cfg: cfg, out: out}
+	switch args[0] {
+	case "validate":
+		return c.validate(args[1:])
+	case "export":
+		return c.export(args[1:])
+	case "import":
+		return c.importFile(args[1:])
+	case "migrate":
+		return c.migrate(args[1:])
+	case "next":
+		return c.next(args[1:])
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		return fmt.Errorf("perintah '%s' tidak dikenal\n\n%s", args[0], usage)
+	}
+}
+
+type command struct {
+	cfg *config.Config
+	out io.Writer
+}
+
+func (c *command) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	return fs
+}
+
+func (c *command) openStorage() (*storage.UserSchedules, error) {
+	if _, err := os.Stat(c.cfg.DBPath); err != nil {
+		return nil, fmt.Errorf("file data %s tidak dapat dibaca: %w", c.cfg.DBPath, err)
+	}
+	stor, err := storage.NewUserSchedules(c.cfg.DBPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w (coba jalankan 'turschedule migrate')", err)
+	}
+	return stor, nil
+}
+
+func (c *command) validate(args []string) error {
+	fs := c.flags("validate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	stor, err := c.openStorage()
+	if err != nil {
+		return err
+	}
+
+	schedules := stor.GetAllSchedules()
+	sortSchedules(schedules)
+
+	invalid := 0
+	titles := make(map[string]string)
+	for _, s := range schedules {
+		var problems []string
+		if err := s.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+		if len(s.ID) > storage.MaxIDLength {
+			problems = append(problems, fmt.Sprintf("id lebih dari %d karakter (jalankan 'turschedule migrate')", storage.MaxIDLength))
+		}
+		key := fmt.Sprintf("%d/%s", s.UserID, s.Title)
+		if other, exists := titles[key]; exists {
+			problems = append(problems, fmt.Sprintf("judul sama dengan jadwal %s", other))
+		}
+		titles[key] = s.ID
+
+		if len(problems) > 0 {
+			invalid++
+			fmt.Fprintf(c.out, "❌ %s (user %d, %q): %s\n", s.ID, s.UserID, s.Title, strings.Join(problems, "; "))
+		}
+	}
+
+	fmt.Fprintf(c.out, "%d jadwal diperiksa, %d tidak valid\n", len(schedules), invalid)
+	if invalid > 0 {
+		return fmt.Errorf("ditemukan %d jadwal tidak valid", invalid)
+	}
+	return nil
+}
+
+func (c *command) migrate(args []string) error {
+	fs := c.flags("migrate")
+	dryRun := fs.Bool("dry-run", false, "tampilkan perubahan tanpa menulis file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := storage.Migrate(c.cfg.DBPath, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if report.Legacy {
+		fmt.Fprintln(c.out, "Format lama (per user) dikonversi ke format per ID")
+	}
+	for _, change := range report.Changes {
+		fmt.Fprintln(c.out, "•", change)
+	}
+	if *dryRun {
+		fmt.Fprintf(c.out, "Dry run: %d jadwal akan ditulis ke %s\n", report.Schedules, c.cfg.DBPath)
+	} else {
+		fmt.Fprintf(c.out, "✅ %d jadwal ditulis ke %s (backup: %s.bak)\n", report.Schedules, c.cfg.DBPath, c.cfg.DBPath)
+	}
+	return nil
+}
+
+func (c *command) next(args []string) error {
+	fs := c.flags("next")
+	userID := fs.Int64("user", 0, "ID user Telegram")
+	count := fs.Int("count", 10, "jumlah jadwal yang ditampilkan")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == 0 {
+		return fmt.Errorf("--user wajib diisi")
+	}
+
+	stor, err := c.openStorage()
+	if err != nil {
+		return err
+	}
+	loc, err := c.location(*userID)
+	if err != nil {
+		return err
+	}
+
+	type firing struct {
+		at       time.Time
+		schedule *storage.Schedule
+	}
+
+	now := time.Now().In(loc)
+	var firings []firing
+	for _, s := range stor.GetUserSchedules(*userID) {
+		for _, at := range s.NextOccurrences(now, *count) {
+			firings = append(firings, firing{at: at, schedule: s})
+		}
+	}
+	sort.Slice(firings, func(i, j int) bool { return firings[i].at.Before(firings[j].at) })
+	if len(firings) > *count {
+		firings = firings[:*count]
+	}
+
+	if len(firings) == 0 {
+		fmt.Fprintf(c.out, "Tidak ada jadwal yang akan datang untuk user %d\n", *userID)
+		return nil
+	}
+
+	for _, f := range firings {
+		var reminders []string
+		for _, minutes := range f.schedule.ReminderTimes {
+			reminders = append(reminders, f.at.Add(-time.Duration(minutes)*time.Minute).Format("15:04"))
+		}
+		line := fmt.Sprintf("%s  %s", f.at.Format("Mon 2006-01-02 15:04 MST"), f.schedule.Title)
+		if len(reminders) > 0 {
+			line += fmt.Sprintf("  (pengingat: %s)", strings.Join(reminders, ", "))
+		}
+		fmt.Fprintln(c.out, line)
+	}
+	return nil
+}
+
+// location returns the time zone userID chose in /settings, or TIMEZONE
+// when they did not choose one or the users file does not exist yet.
+func (c *command) location(userID int64) (*time.Location, error) {
+	if _, err := os.Stat(c.cfg.UsersDBPath); os.IsNotExist(err) {
+		return c.cfg.Location, nil
+	}
+	users, err := storage.NewUsers(c.cfg.UsersDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("file user %s tidak dapat dibaca: %w", c.cfg.UsersDBPath, err)
+	}
+
+	tz := users.Preferences(userID).Timezone
+	if tz == "" {
+		return c.cfg.Location, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("zona waktu '%s' milik user %d tidak valid: %w", tz, userID, err)
+	}
+	return loc, nil
+}
+
+// sortSchedules orders schedules by user, then title, for stable output.
+func sortSchedules(schedules []*storage.Schedule) {
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].UserID != schedules[j].UserID {
+			return schedules[i].UserID < schedules[j].UserID
+		}
+		return schedules[i].Title < schedules[j].Title
+	})
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"turschedule/config"
+	"turschedule/internal/storage"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	return &config.Config{
+		DBPath:      filepath.Join(dir, "schedules.json"),
+		UsersDBPath: filepath.Join(dir, "users.json"),
+		Location:    time.UTC,
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		args    []string
+		legacy  bool
+		want    map[string]storage.Schedule
+		written bool
+	}{
+		{
+			name:   "legacy layout",
+			data:   `{"42": [{"id": "42_1", "title": "Rapat", "time": "9:5", "days": ["senin", "Rabu"]}]}`,
+			legacy: true,
+			want: map[string]storage.Schedule{
+				"42_1": {UserID: 42, Title: "Rapat", Time: "09:05", Days: []string{"Monday", "Wednesday"},
+					ReminderType: storage.ReminderRecurring, ReminderTimes: []int{60, 30, 5}},
+			},
+			written: true,
+		},
+		{
+			name: "current layout",
+			data: `{"42_1": {"user_id": 42, "title": "Rapat", "time": "09:00", "days": ["Monday"], "reminder_type": "once", "reminder_times": [15]}}`,
+			want: map[string]storage.Schedule{
+				"42_1": {UserID: 42, Title: "Rapat", Time: "09:00", Days: []string{"Monday"},
+					ReminderType: storage.ReminderOnce, ReminderTimes: []int{15}},
+			},
+			written: true,
+		},
+		{
+			name:   "dry run",
+			data:   `{"42": [{"id": "42_1", "title": "Rapat", "time": "9:5", "days": ["senin"]}]}`,
+			args:   []string{"--dry-run"},
+			legacy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			if err := os.WriteFile(cfg.DBPath, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if err := Run(cfg, append([]string{"migrate"}, tt.args...), &out); err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			if got := strings.Contains(out.String(), "Format lama"); got != tt.legacy {
+				t.Errorf("legacy reported = %v, want %v\n%s", got, tt.legacy, out.String())
+			}
+
+			data, err := os.ReadFile(cfg.DBPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.written {
+				if string(data) != tt.data {
+					t.Errorf("dry run changed the file:\n%s", data)
+				}
+				if _, err := os.Stat(cfg.DBPath + ".bak"); !os.IsNotExist(err) {
+					t.Errorf("dry run wrote a backup")
+				}
+				return
+			}
+
+			backup, err := os.ReadFile(cfg.DBPath + ".bak")
+			if err != nil || string(backup) != tt.data {
+				t.Errorf("backup = %q, %v; want the original file", backup, err)
+			}
+			var got map[string]storage.Schedule
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("migrated file: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d schedules, want %d", len(got), len(tt.want))
+			}
+			for id, want := range tt.want {
+				s, ok := got[id]
+				if !ok {
+					t.Fatalf("schedule %s missing", id)
+				}
+				if s.UserID != want.UserID || s.Title != want.Title || s.Time != want.Time ||
+					strings.Join(s.Days, ",") != strings.Join(want.Days, ",") ||
+					s.ReminderType != want.ReminderType || !equalInts(s.ReminderTimes, want.ReminderTimes) {
+					t.Errorf("schedule %s = %+v, want %+v", id, s, want)
+				}
+			}
+
+			// The migrated file opens and validates
+			if err := Run(cfg, []string{"validate"}, &out); err != nil {
+				t.Errorf("validate after migrate: %v", err)
+			}
+		})
+	}
+}
+
+func TestNextUsesOwnerTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		zone     string
+	}{
+		{"default", "", "UTC"},
+		{"settings", "Asia/Makassar", "WITA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			stor, err := storage.NewUserSchedules(cfg.DBPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := stor.AddSchedule(&storage.Schedule{
+				UserID: 42, Title: "Rapat", Time: "09:00", Days: storage.Weekdays,
+				ReminderType: storage.ReminderRecurring, ReminderSent: map[string]bool{},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.timezone != "" {
+				users, err := storage.NewUsers(cfg.UsersDBPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := users.SetPreferences(42, storage.UserPreferences{Timezone: tt.timezone}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+			if err := Run(cfg, []string{"next", "--user", "42", "--count", "1"}, &out); err != nil {
+				t.Fatalf("next: %v", err)
+			}
+			if !strings.Contains(out.String(), " 09:00 "+tt.zone+"  Rapat") {
+				t.Errorf("next = %q, want 09:00 %s", out.String(), tt.zone)
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
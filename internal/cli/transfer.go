@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"turschedule/internal/ical"
+	"turschedule/internal/storage"
+)
+
+var csvHeader = []string{"id", "user_id", "title", "time", "days", "note", "reminder_type", "reminder_times", "tags", "duration", "quiet_mode", "nag_every", "nag_max"}
+
+func (c *command) export(args []string) error {
+	fs := c.flags("export")
+	userID := fs.Int64("user", 0, "ID user Telegram")
+	format := fs.String("format", "json", "format ekspor: json, ics atau csv")
+	output := fs.String("out", "", "file tujuan (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == 0 {
+		return fmt.Errorf("--user wajib diisi")
+	}
+
+	stor, err := c.openStorage()
+	if err != nil {
+		return err
+	}
+	schedules := stor.GetUserSchedules(*userID)
+	sortSchedules(schedules)
+	loc, err := c.location(*userID)
+	if err != nil {
+		return err
+	}
+
+	w := c.out
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("gagal membuat file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "json":
+		if schedules == nil {
+			schedules = []*storage.Schedule{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(schedules)
+	case "ics":
+		holidays, err := ical.LoadHolidayCalendars(c.cfg.HolidaysDir, loc)
+		if err != nil {
+			return err
+		}
+		return ical.Encode(w, schedules, holidays, loc, time.Now())
+	case "csv":
+		return writeCSV(w, schedules)
+	default:
+		return fmt.Errorf("format '%s' tidak didukung (json, ics, csv)", *format)
+	}
+}
+
+func (c *command) importFile(args []string) error {
+	fs := c.flags("import")
+	path := fs.String("file", "", "file sumber")
+	format := fs.String("format", "", "format file: json, ics atau csv (default: dari ekstensi)")
+	userID := fs.Int64("user", 0, "pakai ID user ini untuk semua jadwal")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("--file wajib diisi")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*path), ".")
+	}
+
+	f, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var schedules []*storage.Schedule
+	switch *format {
+	case "json":
+		schedules, err = readJSON(f)
+	case "csv":
+		schedules, err = readCSV(f)
+	case "ics":
+		var warnings []*ical.SkipError
+		schedules, warnings, err = ical.Decode(f, c.cfg.Location, time.Now())
+		for _, w := range warnings {
+			fmt.Fprintf(c.out, "⏭️  %s\n", w)
+		}
+	default:
+		return fmt.Errorf("format '%s' tidak didukung (json, ics, csv)", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	stor, err := storage.NewUserSchedules(c.cfg.DBPath)
+	if err != nil {
+		return fmt.Errorf("%w (coba jalankan 'turschedule migrate')", err)
+	}
+
+	imported, skipped := 0, 0
+	for _, s := range schedules {
+		if *userID != 0 {
+			s.UserID = *userID
+		}
+		if s.UserID == 0 {
+			fmt.Fprintf(c.out, "⏭️  %q dilewati: user_id kosong (gunakan --user)\n", s.Title)
+			skipped++
+			continue
+		}
+		if err := s.Validate(); err != nil {
+			fmt.Fprintf(c.out, "⏭️  %q dilewati: %v\n", s.Title, err)
+			skipped++
+			continue
+		}
+		if stor.IsTitleExists(s.UserID, s.Title) {
+			fmt.Fprintf(c.out, "⏭️  %q dilewati: judul sudah ada untuk user %d\n", s.Title, s.UserID)
+			skipped++
+			continue
+		}
+
+		// Keep the id unless it is already taken by another schedule;
+		// AddSchedule also replaces ids that are too long for buttons
+		if _, err := stor.GetSchedule(s.ID); err == nil {
+			s.ID = ""
+		}
+		s.ReminderSent = make(map[string]bool)
+		if err := stor.AddSchedule(s); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	fmt.Fprintf(c.out, "✅ %d jadwal diimpor, %d dilewati\n", imported, skipped)
+	return nil
+}
+
+// readJSON accepts either an exported array or the data file layout keyed
+// by schedule ID.
+func readJSON(r io.Reader) ([]*storage.Schedule, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*storage.Schedule
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+
+	var byID map[string]*storage.Schedule
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return nil, fmt.Errorf("gagal parse JSON: %w", err)
+	}
+	for _, s := range byID {
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func writeCSV(w io.Writer, schedules []*storage.Schedule) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, s := range schedules {
+		var reminders []string
+		for _, m := range s.ReminderTimes {
+			reminders = append(reminders, strconv.Itoa(m))
+		}
+		record := []string{
+			s.ID,
+			strconv.FormatInt(s.UserID, 10),
+			s.Title,
+			s.Time,
+			strings.Join(s.Days, ";"),
+			s.Note,
+			s.ReminderType,
+			strings.Join(reminders, ";"),
+			strings.Join(s.Tags, ";"),
+			strconv.Itoa(s.Duration),
+			s.QuietMode,
+			strconv.Itoa(s.NagEvery),
+			strconv.Itoa(s.NagMax),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func readCSV(r io.Reader) ([]*storage.Schedule, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("gagal parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"title", "time", "days"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("kolom '%s' tidak ditemukan di header CSV", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var schedules []*storage.Schedule
+	for line, record := range records[1:] {
+		s := &storage.Schedule{
+			ID:           field(record, "id"),
+			Title:        field(record, "title"),
+			Time:         field(record, "time"),
+			Note:         field(record, "note"),
+			ReminderType: field(record, "reminder_type"),
+		}
+		if raw := field(record, "user_id"); raw != "" {
+			if s.UserID, err = strconv.ParseInt(raw, 10, 64); err != nil {
+				return nil, fmt.Errorf("baris %d: user_id '%s' tidak valid", line+2, raw)
+			}
+		}
+		for _, day := range strings.Split(field(record, "days"), ";") {
+			if day = strings.TrimSpace(day); day != "" {
+				s.Days = append(s.Days, day)
+			}
+		}
+		if raw := field(record, "reminder_times"); raw != "" {
+			for _, m := range strings.Split(raw, ";") {
+				minutes, err := strconv.Atoi(strings.TrimSpace(m))
+				if err != nil {
+					return nil, fmt.Errorf("baris %d: reminder_times '%s' tidak valid", line+2, raw)
+				}
+				s.ReminderTimes = append(s.ReminderTimes, minutes)
+			}
+		} else {
+			s.ReminderTimes = append([]int(nil), storage.DefaultReminderTimes...)
+		}
+		if s.ReminderType == "" {
+			s.ReminderType = storage.ReminderRecurring
+		}
+		if raw := field(record, "tags"); raw != "" {
+			if s.Tags, err = storage.ParseTags(strings.ReplaceAll(raw, ";", ",")); err != nil {
+				return nil, fmt.Errorf("baris %d: %v", line+2, err)
+			}
+		}
+		if raw := field(record, "duration"); raw != "" {
+			if s.Duration, err = strconv.Atoi(strings.TrimSpace(raw)); err != nil {
+				return nil, fmt.Errorf("baris %d: duration '%s' tidak valid", line+2, raw)
+			}
+		}
+		s.QuietMode = strings.TrimSpace(field(record, "quiet_mode"))
+		for name, target := range map[string]*int{"nag_every": &s.NagEvery, "nag_max": &s.NagMax} {
+			if raw := field(record, name); raw != "" {
+				if *target, err = strconv.Atoi(strings.TrimSpace(raw)); err != nil {
+					return nil, fmt.Errorf("baris %d: %s '%s' tidak valid", line+2, name, raw)
+				}
+			}
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, nil
+}
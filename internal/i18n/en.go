@@ -0,0 +1,465 @@
+package i18n
+
+var english = map[string]string{
+	"language.name":     "English",
+	"language.prompt":   "Choose a language:",
+	"language.set":      "✅ Language set to English.",
+	"language.invalid":  "Unknown language. Options: id, en.",
+	"language.button":   "🇬🇧 English",
+	"help":              helpEN,
+	"help.hint":         "Type /help for help.",
+	"command.unknown":   "Unknown command. Type /help for help.",
+	"cancelled":         "Cancelled. Type /help for help.",
+	"choice.invalid":    "Invalid choice. Pick one of the buttons.",
+	"field.invalid":     "Invalid field. Pick one of the buttons.",
+	"error":             "Error: %v",
+	"schedules.none":    "You have no schedules yet. Use /add to create one.",
+	"schedule.notfound": "❌ No schedule with that title was found.",
+	"group.admin_only":  "⛔ Only group admins can do this.",
+
+	"label.title":    "📌 Title: %s\n",
+	"label.time":     "⏰ Time: %s\n",
+	"label.days":     "📆 Days: %s\n",
+	"label.note":     "📝 Note: %s\n",
+	"label.tags":     "🏷️ Tags: %s\n",
+	"label.duration": "⏱️ Duration: %s\n",
+	"label.reminder": "🔁 Reminder: %s",
+
+	"type.once":             "Once",
+	"type.recurring":        "Recurring",
+	"type.recurring_weekly": "Recurring (every week)",
+
+	"ask.title":         "Enter the schedule name:",
+	"ask.new_title":     "Enter the new title:",
+	"ask.time":          "Choose a time:",
+	"ask.days":          "Choose the days (you can pick more than one):",
+	"ask.note":          "Enter a note (optional, or type '-'):",
+	"ask.note_edit":     "Enter the note:",
+	"ask.quiet_mode":    "What should happen to reminders that fall in quiet hours?",
+	"ask.duration":      "How long does it last? Type a duration (e.g. 90 minutes, 1.5 hours) or an end time (e.g. 10:30), or choose 'No duration':",
+	"ask.tags":          "Enter tags separated by commas (e.g. class, work), pick an existing tag, or type '-':",
+	"ask.reminder_type": "Choose the reminder type:",
+	"ask.field":         "Choose the field to change:",
+	"ask.new_value":     "Enter the new %s:",
+	"ask.pick_value":    "Choose the new %s:",
+	"ask.edit_title":    "Enter the title of the schedule to change:",
+	"ask.delete_title":  "Enter the title of the schedule to delete:",
+	"ask.edit_continue": "Do you want to change another field?",
+
+	"field.title":    "title",
+	"field.time":     "time",
+	"field.days":     "days",
+	"field.note":     "note",
+	"field.tags":     "tags",
+	"field.duration": "duration",
+	"field.quiet":    "quiet hours",
+
+	"title.exists":       "❌ That title already exists. Use a different title.",
+	"title.exists_named": "❌ The title %s already exists. Use a different title.",
+	"days.min_one":       "Choose at least one day!",
+	"days.invalid":       "Invalid day format.",
+	"days.invalid_pick":  "Invalid day format. Pick one of the buttons.",
+	"days.invalid_hint":  "Invalid day format. Example: Monday, Wednesday",
+	"days.already":       "Day already chosen. Pick another day or press %s",
+	"days.selected":      "Chosen days: %s",
+	"days.picked":        "✅ %s chosen",
+
+	"schedule.created":      "✅ <b>Schedule added!</b>\n📌 %s\n⏰ Reminder: %s (1h, 30m, 5m before the scheduled time)",
+	"field.updated":         "✅ %s updated!",
+	"edit.done":             "Done editing. Type /help for help.",
+	"schedule.deleted":      "✅ Schedule deleted!",
+	"delete.failed":         "Failed to delete the schedule.",
+	"note.none":             "(no note)",
+	"notify.main":           "🔔 <b>IT'S TIME!</b>\n📌 %s\n⏰ Time: %s\n📝 %s",
+	"notify.reminder.one":   "⏰ <b>Reminder, %d minute to go:</b>\n📌 %s\n📝 %s\n⏰ Time: %s",
+	"notify.reminder.other": "⏰ <b>Reminder, %d minutes to go:</b>\n📌 %s\n📝 %s\n⏰ Time: %s",
+
+	"time.invalid":       "Invalid time format. Examples: 09:30, 9.30, 21.15 or 9:30 pm",
+	"time.type_or_pick":  "Type a time (e.g. 09:30, 9.30, 9:30 pm) or pick one below.",
+	"time.pick_hour":     "🕐 Pick the hour:",
+	"time.pick_minute":   "🕐 %s o'clock, pick the minute:",
+	"time.picked":        "⏰ Time chosen: %s",
+	"time.picker_closed": "This time picker has expired.",
+
+	"draft.header":     "🧠 <b>Here is what I understood:</b>\n\n",
+	"draft.confirm":    "Save this schedule?",
+	"draft.ask_time":   "At what time?",
+	"draft.ask_days":   "On which days? Type the days (e.g. Monday, Wednesday or every weekday):",
+	"draft.many_times": "⚠️ A schedule has a single time, but I found %s. Create separate schedules for the other times.",
+	"draft.past":       "⏰ %s today has already passed.",
+	"draft.too_far":    "📅 %s cannot be scheduled yet: one-off reminders go off on the nearest chosen day.",
+
+	"export.caption.one":   "📤 %d schedule exported. Open this file to add it to your calendar app.",
+	"export.caption.other": "📤 %d schedules exported. Open this file to add them to your calendar app.",
+	"export.failed":        "❌ Failed to create the calendar file.",
+	"export.send_failed":   "❌ Failed to send the calendar file.",
+
+	"feed.disabled": "Calendar subscriptions have not been enabled by the bot admin.",
+	"feed.failed":   "❌ Failed to create the calendar link.",
+	"feed.link":     "📅 <b>Your calendar subscription link:</b>\n%s",
+	"feed.webcal":   "\n\nOpen directly in your calendar app:\nwebcal://%s",
+	"feed.howto":    "\n\nAdd this link as a subscribed calendar. Changes made with /add and /edit will sync automatically.",
+	"feed.reset":    "🔄 The old link no longer works.\n\n",
+	"feed.warning":  "\n\n⚠️ Do not share this link. Type /feed reset to create a new one.",
+
+	"import.only_ics":        "Only .ics calendar files can be imported.",
+	"import.too_large":       "❌ The file is too large (1 MB maximum).",
+	"import.download_failed": "❌ Failed to download the file.",
+	"import.nothing":         "There are no events in this file that can be imported.",
+	"import.not_calendar":    "❌ This file is not a valid iCalendar file.",
+	"import.read_failed":     "❌ Failed to read the file.",
+	"import.skip.no_start":   "no start time",
+	"import.skip.all_day":    "all-day events have no time",
+	"import.skip.bad_time":   "invalid time <code>%s</code>",
+	"import.skip.past":       "the event is over",
+	"import.skip.too_far":    "one-off event is more than a week away",
+	"import.skip.interval":   "repeating every %s periods is not supported yet",
+	"import.skip.count":      "repeats that stop after %s times are not supported yet",
+	"import.skip.until":      "repeats ending on %s are not supported yet",
+	"import.skip.ended":      "the repeats have ended",
+	"import.skip.byday":      "invalid day <code>%s</code>",
+	"import.skip.frequency":  "<code>%s</code> repeats are not supported yet",
+	"import.found.one":       "📥 <b>%d schedule found:</b>\n\n",
+	"import.found.other":     "📥 <b>%d schedules found:</b>\n\n",
+	"import.title_exists":    "   ⚠️ Title already exists, will be skipped\n",
+	"import.not_imported":    "\nNot imported:\n",
+	"import.all_exist":       "\nAll titles already exist. There are no new schedules to save.",
+	"import.save.one":        "\nSave %d schedule?",
+	"import.save.other":      "\nSave %d schedules?",
+	"import.confirm":         "Save the schedules from the file?",
+	"import.saved.one":       "✅ %d schedule imported!",
+	"import.saved.other":     "✅ %d schedules imported!",
+	"import.skipped.one":     "\n⏭️ %d schedule skipped.",
+	"import.skipped.other":   "\n⏭️ %d schedules skipped.",
+
+	"agenda.today":          "📅 <b>Today's agenda</b>, %s\n",
+	"agenda.tomorrow":       "📅 <b>Tomorrow's agenda</b>, %s\n",
+	"agenda.week":           "📅 <b>Agenda for the next 7 days</b>\n",
+	"agenda.next.one":       "⏭️ <b>Next %d schedule:</b>\n",
+	"agenda.next.other":     "⏭️ <b>Next %d schedules:</b>\n",
+	"agenda.empty.today":    "Nothing scheduled today. 🎉",
+	"agenda.empty.tomorrow": "Nothing scheduled tomorrow. 🎉",
+	"agenda.empty.week":     "Nothing scheduled in the next 7 days.",
+	"agenda.empty.next":     "There are no upcoming schedules.",
+	"agenda.next.invalid":   "Invalid count. Example: /next 5",
+	"agenda.in":             "in %s",
+	"agenda.soon":           "any moment now",
+	"agenda.past":           "already passed",
+
+	"list.header":            "📅 <b>Your schedules (%d)</b> • sorted by %s • page %d/%d\n",
+	"list.sort.next":         "next occurrence",
+	"list.sort.title":        "title",
+	"list.sort.created":      "newest first",
+	"list.sort.invalid":      "Unknown sort order. Options: /list next, /list title, /list created.",
+	"list.button.next":       "🕐 Next",
+	"list.button.title":      "🔤 Title",
+	"list.button.created":    "🆕 Newest",
+	"list.paused":            "⏸️ Paused",
+	"list.paused_until":      "⏸️ Paused until %s",
+	"list.skipped":           "🚫 Skipped: %s",
+	"list.holidays":          "🎌 Holidays: %s",
+	"list.priority":          "🚨 Priority: repeated every %d minutes, at most %d times",
+	"list.quiet":             "🌙 Quiet hours: %s",
+	"list.created_by":        "👤 Added by %s",
+	"list.subscribers.one":   "👥 %d subscriber",
+	"list.subscribers.other": "👥 %d subscribers",
+	"list.legend":            "✏️ edit • 🗑️ delete • ⏸️ pause / ▶️ resume • 📄 duplicate",
+	"list.delete_confirm":    "Delete the schedule %s?",
+	"list.gone":              "That schedule no longer exists.",
+	"list.editing":           "✏️ Editing %s.",
+	"list.copy_title":        "%s (copy)",
+	"list.copy_title_n":      "%s (copy %d)",
+
+	"pause.usage":        "Type /pause &lt;title&gt; to pause a schedule, or /pause &lt;title&gt; until &lt;date&gt; to pause it for a while.\nExample: /pause Team Meeting until 2 November",
+	"pause.done":         "⏸️ %s is paused. Type /resume %s to resume it.",
+	"pause.until":        "⏸️ %s is paused and runs again from %s.",
+	"pause.bad_date":     "Date not recognized. Examples: 2 November, 02/11 or 2026-11-02.",
+	"pause.past_date":    "The resume date must be after today.",
+	"resume.usage":       "Type /resume &lt;title&gt; to resume a paused schedule.",
+	"resume.done":        "▶️ %s is running again.",
+	"resume.next":        "⏭️ Next: %s",
+	"resume.not_paused":  "%s is not paused.",
+	"skip.usage":         "Type /skip &lt;title&gt; to skip only the next occurrence, or /skip &lt;title&gt; &lt;date&gt; to skip a specific date.\nExample: /skip Class 25 December",
+	"skip.done":          "⏭️ %s on %s is skipped.",
+	"skip.none":          "%s has no upcoming occurrence to skip.",
+	"skip.once":          "One-time schedules cannot be skipped. Use /delete to remove it.",
+	"skip.past_date":     "The skipped date must be in the future.",
+	"skip.not_scheduled": "%s does not run on %s.",
+
+	"holiday.header":       "🎌 <b>Holiday calendars</b>\n",
+	"holiday.next":         "next: %s, %s",
+	"holiday.usage":        "Type /holiday &lt;title&gt; &lt;calendar&gt; so the schedule does not run on that calendar's holidays. Type it again to undo.\nExample: /holiday Class id",
+	"holiday.no_calendars": "There are no holiday calendars yet. The bot admin can put .ics or .json files in the HOLIDAYS_DIR directory.",
+	"holiday.unknown":      "There is no holiday calendar %s. Options: %s",
+	"holiday.once":         "One-time schedules cannot follow a holiday calendar.",
+	"holiday.added":        "🎌 %s will not run on %s holidays.",
+	"holiday.removed":      "%s runs on %s holidays again.",
+	"holiday.skipped":      "skipped: %s holiday",
+
+	"digest.daily.one":     "🌅 <b>Good morning!</b> Agenda for %s (%d schedule)\n",
+	"digest.daily.other":   "🌅 <b>Good morning!</b> Agenda for %s (%d schedules)\n",
+	"digest.weekly.one":    "🗓️ <b>Next week at a glance</b> from %s (%d schedule)\n",
+	"digest.weekly.other":  "🗓️ <b>Next week at a glance</b> from %s (%d schedules)\n",
+	"digest.settings":      "📬 <b>Schedule digests</b>\n\n🌅 Daily: %s\n🗓️ Weekly (Sunday 19:00): %s\n\nThe daily digest lists all of that day's schedules; the weekly one covers the coming Monday to Sunday. Days without schedules get no message.",
+	"digest.off":           "off",
+	"digest.on":            "on",
+	"digest.on_at":         "on, at %s",
+	"digest.ask_time":      "What time should the daily digest be sent?",
+	"digest.time_saved":    "🌅 The daily digest is sent every day at %s.",
+	"digest.button.daily":  "Daily",
+	"digest.button.weekly": "Weekly",
+	"digest.button.time":   "⏰ Change time",
+
+	"settings.header":            "⚙️ <b>Settings</b>\n\n🌐 Language: %s\n🕐 Time zone: %s\n🔔 Reminders: %s before\n🔁 Type of schedules from a sentence: %s\n🌙 Quiet hours: %s\n🕘 Working hours: %s\n🌅 Daily digest: %s\n🗓️ Weekly digest: %s\n\nDefault reminders and type apply to new schedules.",
+	"settings.default":           "(default)",
+	"settings.ask.timezone":      "Which time zone should be used? Type a name such as Europe/London or pick WIB, WITA, WIT.",
+	"settings.ask.reminders":     "How long before the event should reminders be sent? Separate them with commas, e.g. 60, 30, 5 or 1 hour, 15 min.",
+	"settings.ask.type":          "Which type should be used when a sentence does not say once or recurring?",
+	"settings.ask.quiet":         "During which hours should reminders stay silent? For example 22:00-06:00.",
+	"settings.ask.workhours":     "Working hours for /free? For example 08:00-17:00.",
+	"settings.reminders_invalid": "Invalid reminder times. Example: 60, 30, 5 (at most 7 days).",
+	"settings.saved":             "✅ Settings saved.",
+	"settings.button.language":   "🌐 Language",
+	"settings.button.timezone":   "🕐 Time zone",
+	"settings.button.reminders":  "🔔 Reminders",
+	"settings.button.type":       "🔁 Default type",
+	"settings.button.quiet":      "🌙 Quiet hours",
+	"settings.button.workhours":  "🕘 Working hours",
+	"settings.button.digest":     "🌅 Digests",
+
+	"nag.repeat":         "🚨 <b>NOT DONE YET:</b> %s (%s)\nRepeat %d of %d. Tap ✅ Done once it is.",
+	"nag.done":           "✅ %s marked as done by %s.",
+	"habit.streak.one":   "🔥 %d time in a row!",
+	"habit.streak.other": "🔥 %d times in a row!",
+	"stats.header":       "📊 <b>Habit statistics</b>",
+	"stats.line":         "🔥 %d streak • 🏆 longest %d • ✅ %d/%d (%d%%)",
+	"stats.detail":       "📊 <b>%s</b>\n🔥 Streak: %d • 🏆 Longest: %d\n✅ Done %d of %d (%d%%)",
+	"stats.legend":       "Columns Monday → Sunday\n✅ done • ❌ missed • ⏳ not done yet today • ➖ skipped/paused • ▫️ upcoming • ⬜ not scheduled",
+	"stats.hint":         "Tap ✅ Done on a notification to record it. Type /stats &lt;title&gt; for the weekly heatmap.",
+	"stats.empty":        "There are no recurring schedules to count yet.",
+	"stats.once":         "Statistics are only available for recurring schedules.",
+	"priority.usage":     "Usage: /priority &lt;title&gt; [minutes] [max]\nThe main notification of a priority schedule repeats every few minutes (default 5) until ✅ Done is tapped, up to a number of times (default 6).\n\nExample: /priority Take Medicine 10 3\nTurn off: /priority Take Medicine off",
+	"priority.on":        "🚨 %s is now a priority: the notification repeats every %d minutes, at most %d times, until marked as done.",
+	"priority.off":       "%s is no longer a priority schedule.",
+
+	"duplicate.usage":     "Type /duplicate &lt;title&gt; to copy a schedule under a new title.\nExample: /duplicate Calculus",
+	"duplicate.ask_title": "Enter the title for the copy of %s:",
+	"duplicate.ask_edit":  "Change anything in %s now, such as the time or days?",
+	"template.header":     "📋 <b>Templates (%d)</b>\n",
+	"template.none":       "No templates yet.\n\nSave a schedule as a template with /template &lt;title&gt;, then create similar schedules with one tap.",
+	"template.legend":     "➕ create schedule • 🗑️ delete\nSave a new template with /template &lt;title&gt;.",
+	"template.ask_title":  "Enter the title pattern for the template from %s. {n} is replaced by a running number, so <code>Lecture {n}</code> becomes Lecture 1, Lecture 2 and so on:",
+	"template.saved":      "📋 Template %s saved. Type /template to create schedules from it.",
+	"template.gone":       "That template no longer exists.",
+
+	"bulk.header":               "☑️ <b>Bulk actions</b> • %d of %d schedules selected\nTick schedules, then pick an action below.",
+	"bulk.button.all":           "☑️ Select all",
+	"bulk.button.none":          "⬜ Clear",
+	"bulk.button.del":           "🗑️ Delete",
+	"bulk.button.pause":         "⏸️ Pause",
+	"bulk.button.resume":        "▶️ Resume",
+	"bulk.button.tag":           "🏷️ Retag",
+	"bulk.button.shift":         "🕐 Shift time",
+	"bulk.button.type":          "🔁 Type",
+	"bulk.button.notag":         "🚫 No tags",
+	"bulk.button.undo":          "⏪ Undo",
+	"bulk.delete_confirm.one":   "Delete this %d schedule?",
+	"bulk.delete_confirm.other": "Delete these %d schedules?",
+	"bulk.ask_type.one":         "Change the reminder type of %d schedule to:",
+	"bulk.ask_type.other":       "Change the reminder type of %d schedules to:",
+	"bulk.ask_tag.one":          "Type the new tags for %d schedule, such as <code>#lecture #term1</code>. The old tags are replaced.",
+	"bulk.ask_tag.other":        "Type the new tags for %d schedules, such as <code>#lecture #term1</code>. The old tags are replaced.",
+	"bulk.ask_shift.one":        "Shift the time of %d schedule by how many minutes? Pick below or type, such as <code>+45</code>, <code>-90</code> or <code>+1 hour</code>.",
+	"bulk.ask_shift.other":      "Shift the time of %d schedules by how many minutes? Pick below or type, such as <code>+45</code>, <code>-90</code> or <code>+1 hour</code>.",
+	"bulk.tag_invalid":          "Type at least one tag, such as #lecture, or tap 🚫 No tags.",
+	"bulk.shift_invalid":        "Type a shift such as +30, -15 or +1 hour (less than 24 hours).",
+	"bulk.no_tags":              "no tags",
+	"bulk.none_selected":        "Tick at least one schedule first.",
+	"bulk.expired":              "This selection has expired. Type /bulk to start again.",
+	"bulk.use_buttons":          "Use the buttons on the list to pick schedules, or type /bulk to start again.",
+	"bulk.done.del.one":         "🗑️ %d schedule deleted:",
+	"bulk.done.del.other":       "🗑️ %d schedules deleted:",
+	"bulk.done.pause.one":       "⏸️ %d schedule paused:",
+	"bulk.done.pause.other":     "⏸️ %d schedules paused:",
+	"bulk.done.resume.one":      "▶️ %d schedule resumed:",
+	"bulk.done.resume.other":    "▶️ %d schedules resumed:",
+	"bulk.done.tag.one":         "🏷️ Tags of %d schedule set to %s:",
+	"bulk.done.tag.other":       "🏷️ Tags of %d schedules set to %s:",
+	"bulk.done.shift.one":       "🕐 Time of %d schedule shifted by %s:",
+	"bulk.done.shift.other":     "🕐 Time of %d schedules shifted by %s:",
+	"bulk.done.type.one":        "🔁 %d schedule changed to %s:",
+	"bulk.done.type.other":      "🔁 %d schedules changed to %s:",
+	"bulk.undone.one":           "⏪ Changes to %d schedule undone:",
+	"bulk.undone.other":         "⏪ Changes to %d schedules undone:",
+	"bulk.undo_expired":         "This action can no longer be undone.",
+
+	"quiet.off":         "not set",
+	"quiet.mode.silent": "sent silently",
+	"quiet.mode.delay":  "delayed until quiet hours end",
+	"quiet.mode.skip":   "not sent",
+	"quiet.usage":       "🌙 Quiet hours of this chat: %s (set them in /settings).\n\nReminders falling in quiet hours can be:\n• <code>silent</code> — sent without sound (default)\n• <code>delay</code> — held until quiet hours end, or dropped if the event has started\n• <code>skip</code> — not sent\n\nRepeats of priority schedules follow the same mode; a delayed or skipped repeat is not sent and the next one still follows.\n\nExample: /quiet Morning Run delay",
+	"quiet.usage_mode":  "Unknown mode. Choose silent, delay or skip.",
+	"quiet.saved":       "🌙 Reminders of %s in quiet hours are now %s.",
+
+	"duration.invalid":       "Invalid duration. Examples: 45 minutes, 1 hour 30 minutes, or an end time like 10:30. The event must end before midnight.",
+	"duration.past_midnight": "With a duration of %s this event would run past midnight. Pick an earlier time or change the duration first.",
+	"conflict.header.one":    "⚠️ %s overlaps %d other schedule:",
+	"conflict.header.other":  "⚠️ %s overlaps %d other schedules:",
+	"free.usage":             "Type /free &lt;day&gt; to see your free time, e.g. /free monday, /free tomorrow or /free friday 13:00-17:00.",
+	"free.header":            "🟢 <b>Free time</b> %s • %s\n",
+	"free.none":              "No free time within these working hours.",
+	"workhours.current":      "🕘 Working hours for /free: %s\nChange them with /workhours 09:00-18:00 or restore the default with /workhours reset.",
+	"workhours.saved":        "🕘 Working hours for /free are now %s.",
+
+	"tag.filter":     "🏷️ Filter: #%s\n",
+	"tag.none":       "There are no schedules tagged #%s.",
+	"tag.mute_usage": "Type /mute #tag to mute every reminder with that tag, and /unmute #tag to turn them back on.",
+	"tag.muted_list": "🔕 Muted tags: %s",
+	"tag.muted":      "🔕 Reminders tagged %s are muted.",
+	"tag.unmuted":    "🔔 Reminders tagged %s are back on.",
+
+	"share.usage":         "Type /share &lt;title&gt; to create an invite link, /share &lt;title&gt; @username to invite someone directly, or /share &lt;title&gt; reset to replace the link.",
+	"share.link":          "🔗 <b>Invite link for</b> %s:\n%s\n\nAnyone who opens this link can get the same reminders. Your changes apply to them as well.",
+	"share.unknown_user":  "%s has not started this bot yet, so the invite cannot be sent directly. Send them this link:\n%s",
+	"share.already_named": "%s already receives the reminders of %s.",
+	"share.sent":          "✅ Invite to %s sent to %s.",
+	"share.invalid":       "❌ The invite link is invalid or the schedule was deleted.",
+	"share.own":           "This is your own schedule.",
+	"share.already":       "You are already subscribed to %s. See /shared.",
+	"share.invite":        "📨 <b>Schedule invite</b>\n📌 %s\n⏰ %s • 📆 %s",
+	"share.invite_hint":   "Accept to get the same reminders as its owner.",
+	"share.accepted":      "✅ You are subscribed to %s. Mute or leave it any time with /shared.",
+	"share.declined":      "Invite declined.",
+	"share.joined":        "👥 %s subscribed to %s.",
+	"share.updated":       "✏️ The schedule %s was changed by its owner. See /shared.",
+	"share.deleted":       "🗑️ The schedule %s was deleted by its owner. You will not get its reminders anymore.",
+	"share.reset":         "🔄 The old link and invites no longer work. Existing subscribers keep getting the reminders.\n\n",
+	"share.reset_hint":    "\n\nType <code>/share %s reset</code> to replace this link.",
+	"shared.header":       "👥 <b>Schedules shared with you (%d)</b>\n",
+	"shared.none":         "No schedules have been shared with you yet.",
+	"shared.from":         "👤 From %s",
+	"shared.muted":        "🔕 Muted",
+	"rsvp.yes":            "✅ Going",
+	"rsvp.no":             "❌ Not going",
+	"rsvp.maybe":          "🤔 Maybe",
+	"rsvp.usage":          "Type /rsvp &lt;title&gt; to see who is coming.",
+	"rsvp.none":           "Nobody has answered for %s yet. Attendance buttons appear on the reminders of group and shared schedules.",
+	"rsvp.header":         "📋 <b>Attendance</b> %s\n📆 %s • ⏰ %s\n",
+	"shared.legend":       "🔕 mute / 🔔 unmute • 🚪 leave",
+
+	"unit.day.one":      "%d day",
+	"unit.day.other":    "%d days",
+	"unit.hour.one":     "%d hour",
+	"unit.hour.other":   "%d hours",
+	"unit.minute.one":   "%d minute",
+	"unit.minute.other": "%d minutes",
+
+	"button.cancel":         "❌ Cancel",
+	"button.default":        "↩️ Default",
+	"button.days_done":      "🔄 Done Choosing",
+	"button.no_note":        "No note",
+	"button.no_tags":        "No tags",
+	"button.no_duration":    "No duration",
+	"button.once":           "🔔 Once",
+	"button.recurring":      "🔊 Recurring",
+	"button.field.title":    "1️⃣ Title",
+	"button.field.time":     "2️⃣ Time",
+	"button.field.days":     "3️⃣ Days",
+	"button.field.note":     "4️⃣ Note",
+	"button.field.type":     "5️⃣ Type",
+	"button.field.tags":     "🏷️ Tags",
+	"button.field.duration": "⏱️ Duration",
+	"button.field.quiet":    "🌙 Quiet Hours",
+	"button.quiet.silent":   "🔕 Send silently",
+	"button.quiet.delay":    "⏳ Delay",
+	"button.quiet.skip":     "🚫 Skip",
+	"button.edit_more":      "✏️ Edit More",
+	"button.done":           "✅ Done",
+	"button.save":           "✅ Save",
+	"button.edit":           "✏️ Change",
+	"button.save_all":       "✅ Save All",
+	"button.delete_yes":     "🗑️ Yes, delete",
+	"button.back":           "↩️ Back",
+	"button.accept":         "✅ Accept",
+	"button.decline":        "❌ Decline",
+	"button.change_hour":    "⬅️ Change hour",
+
+	"day.Sunday":    "Sunday",
+	"day.Monday":    "Monday",
+	"day.Tuesday":   "Tuesday",
+	"day.Wednesday": "Wednesday",
+	"day.Thursday":  "Thursday",
+	"day.Friday":    "Friday",
+	"day.Saturday":  "Saturday",
+
+	"day_button.Sunday":    "Sunday",
+	"day_button.Monday":    "Monday",
+	"day_button.Tuesday":   "Tuesday",
+	"day_button.Wednesday": "Wednesday",
+	"day_button.Thursday":  "Thursday",
+	"day_button.Friday":    "Friday",
+	"day_button.Saturday":  "Saturday",
+
+	"month.January":   "January",
+	"month.February":  "February",
+	"month.March":     "March",
+	"month.April":     "April",
+	"month.May":       "May",
+	"month.June":      "June",
+	"month.July":      "July",
+	"month.August":    "August",
+	"month.September": "September",
+	"month.October":   "October",
+	"month.November":  "November",
+	"month.December":  "December",
+}
+
+const helpEN = `🤖 <b>Schedule Bot - Help</b>
+
+Available commands:
+/add - Add a new schedule
+/list - Show all schedules (/list title or /list created for other orders, /list #class by tag)
+/today - Today's agenda (/today #work by tag)
+/tomorrow - Tomorrow's agenda
+/week - Agenda for the next 7 days
+/next - Upcoming schedules (/next 10 for 10 schedules)
+/edit - Change a schedule
+/delete - Delete a schedule
+/export - Export schedules to a calendar file (.ics, /export #class by tag)
+/feed - Calendar subscription link (/feed reset for a new link)
+/share - Share a schedule (/share &lt;title&gt;, /share &lt;title&gt; @username or /share &lt;title&gt; reset)
+/shared - Schedules shared with you
+/pause - Pause a schedule (/pause &lt;title&gt; until 2 November)
+/resume - Resume a paused schedule
+/skip - Skip the next occurrence or a specific date (/skip Class 25 December)
+/holiday - Skip national holidays (/holiday Class id)
+/digest - Daily & weekly schedule digests
+/settings - Time zone, reminders & other settings
+/quiet - What reminders do in quiet hours
+/priority - Repeat a notification until marked done
+/stats - Habit statistics & streaks
+/bulk - Delete, pause, retag or shift many schedules at once
+/duplicate - Copy a schedule under a new title
+/template - Schedule templates (/template &lt;title&gt; to save one)
+/free - Free time within working hours (/free monday, /free tomorrow)
+/workhours - Set working hours for /free (/workhours 09:00-18:00)
+/mute - Mute reminders by tag (/mute #personal, /unmute #personal)
+/rsvp - Attendance tally (/rsvp &lt;title&gt;)
+/language - Change language (Bahasa)
+/help - Show this help
+
+Example:
+1. Type /add
+2. Follow the steps to create a new schedule
+3. The bot reminds you on schedule
+
+Or write it in a single sentence:
+/add team meeting every Monday and Wednesday at 9:30
+take medicine every day at 7 pm
+call mom tomorrow at 7 pm
+
+Have schedules in a calendar app? Send the .ics file to the bot to import it.
+
+Add the bot to a group for team schedules: every member can add schedules and reminders are posted to the group, but only admins can change, pause or delete them.
+
+For questions, contact @FtrRahman`
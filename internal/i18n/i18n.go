@@ -0,0 +1,192 @@
+// Package i18n holds the user facing messages of the bot in every supported
+// language, together with localized day and month names.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Lang is a supported interface language, identified by its ISO 639-1 code.
+type Lang string
+
+const (
+	ID Lang = "id"
+	EN Lang = "en"
+
+	// Default is used for users without a stored or detectable language.
+	Default = ID
+)
+
+// Languages lists the supported languages in the order they are offered.
+var Languages = []Lang{ID, EN}
+
+var catalogs = map[Lang]map[string]string{
+	ID: indonesian,
+	EN: english,
+}
+
+// Parse returns the language identified by a code such as "en" or "id".
+func Parse(code string) (Lang, bool) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(code)))
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Detect maps a Telegram language_code such as "en-US" to a supported
+// language. Indonesian and Malay speakers get Indonesian, everyone else
+// English.
+func Detect(code string) Lang {
+	base, _, _ := strings.Cut(strings.ToLower(code), "-")
+	switch base {
+	case "":
+		return Default
+	case "id", "ms":
+		return ID
+	}
+	return EN
+}
+
+// Name is the language name written in the language itself.
+func (l Lang) Name() string {
+	return T(l, "language.name")
+}
+
+// T returns the message key in lang formatted with args. Missing messages
+// fall back to the default language and then to the key itself.
+func T(lang Lang, key string, args ...interface{}) string {
+	msg, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N returns the plural form of key that fits n, formatted with args. Plural
+// forms are stored as "<key>.one" and "<key>.other"; languages without
+// grammatical number only define "<key>.other".
+func N(lang Lang, key string, n int, args ...interface{}) string {
+	if n == 1 {
+		if msg, ok := lookup(lang, key+".one"); ok {
+			return fmt.Sprintf(msg, args...)
+		}
+	}
+	return T(lang, key+".other", args...)
+}
+
+func lookup(lang Lang, key string) (string, bool) {
+	if msg, ok := catalogs[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[Default][key]
+	return msg, ok
+}
+
+// Match reports whether text is the message key in any language. Case and a
+// leading emoji are ignored so typed answers such as "batal" match the
+// "❌ Batal" button.
+func Match(text, key string) bool {
+	text = normalize(text)
+	if text == "" {
+		return false
+	}
+	for _, lang := range Languages {
+		if msg, ok := catalogs[lang][key]; ok && (normalize(msg) == text || stripEmoji(normalize(msg)) == text) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// stripEmoji drops a leading symbol such as "❌ " from a button label.
+func stripEmoji(s string) string {
+	for i, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return strings.TrimSpace(s[i:])
+		}
+	}
+	return s
+}
+
+// DayName returns the localized name of an English weekday name as stored in
+// schedules.
+func DayName(lang Lang, day string) string {
+	return T(lang, "day."+day)
+}
+
+// DayNames localizes and joins a list of stored weekday names.
+func DayNames(lang Lang, days []string) string {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = DayName(lang, day)
+	}
+	return strings.Join(names, ", ")
+}
+
+// DayButton returns the keyboard label of a stored weekday name.
+func DayButton(lang Lang, day string) string {
+	return T(lang, "day_button."+day)
+}
+
+// ParseDay recognizes a weekday name or day button label in any language and
+// returns the English name used in schedules.
+func ParseDay(text string) (string, bool) {
+	text = normalize(text)
+	for _, day := range weekdays {
+		if normalize(day) == text {
+			return day, true
+		}
+		for _, lang := range Languages {
+			if normalize(T(lang, "day."+day)) == text || normalize(T(lang, "day_button."+day)) == text {
+				return day, true
+			}
+		}
+	}
+	return "", false
+}
+
+var weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// FormatDate writes t as a localized long date, e.g. "19 Oktober 2026".
+func FormatDate(lang Lang, t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), T(lang, "month."+t.Month().String()), t.Year())
+}
+
+// FormatWeekdayDate writes t with its weekday, e.g. "Senin, 19 Oktober 2026".
+func FormatWeekdayDate(lang Lang, t time.Time) string {
+	return DayName(lang, t.Weekday().String()) + ", " + FormatDate(lang, t)
+}
+
+// Duration writes d rounded up to the minute using its two largest units,
+// e.g. "2 jam 15 menit" or "3 days 4 hours".
+func Duration(lang Lang, d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	units := []struct {
+		key string
+		n   int
+	}{
+		{"unit.day", minutes / (24 * 60)},
+		{"unit.hour", minutes / 60 % 24},
+		{"unit.minute", minutes % 60},
+	}
+
+	var parts []string
+	for _, u := range units {
+		if u.n > 0 && len(parts) < 2 {
+			parts = append(parts, N(lang, u.key, u.n, u.n))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, N(lang, "unit.minute", 0, 0))
+	}
+	return strings.Join(parts, " ")
+}
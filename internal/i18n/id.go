@@ -0,0 +1,439 @@
+package i18n
+
+var indonesian = map[string]string{
+	"language.name":     "Bahasa Indonesia",
+	"language.prompt":   "Pilih bahasa:",
+	"language.set":      "✅ Bahasa diubah ke Bahasa Indonesia.",
+	"language.invalid":  "Bahasa tidak dikenal. Pilihan: id, en.",
+	"language.button":   "🇮🇩 Bahasa Indonesia",
+	"help":              helpID,
+	"help.hint":         "Ketik /help untuk bantuan.",
+	"command.unknown":   "Perintah tidak dikenal. Ketik /help untuk bantuan.",
+	"cancelled":         "Dibatalkan. Ketik /help untuk bantuan.",
+	"choice.invalid":    "Pilihan tidak valid. Pilih dari tombol yang tersedia.",
+	"field.invalid":     "Field tidak valid. Pilih dari tombol yang tersedia.",
+	"error":             "Error: %v",
+	"schedules.none":    "Anda belum memiliki jadwal. Gunakan /add untuk membuat jadwal baru.",
+	"schedule.notfound": "❌ Jadwal dengan judul tersebut tidak ditemukan.",
+	"group.admin_only":  "⛔ Hanya admin grup yang bisa melakukan ini.",
+
+	"label.title":    "📌 Judul: %s\n",
+	"label.time":     "⏰ Waktu: %s\n",
+	"label.days":     "📆 Hari: %s\n",
+	"label.note":     "📝 Catatan: %s\n",
+	"label.tags":     "🏷️ Tag: %s\n",
+	"label.duration": "⏱️ Durasi: %s\n",
+	"label.reminder": "🔁 Reminder: %s",
+
+	"type.once":             "Sekali",
+	"type.recurring":        "Berkali-kali",
+	"type.recurring_weekly": "Berkali-kali (setiap minggu)",
+
+	"ask.title":         "Masukkan nama jadwal:",
+	"ask.new_title":     "Masukkan judul baru:",
+	"ask.time":          "Pilih waktu:",
+	"ask.days":          "Pilih hari (bisa pilih lebih dari satu):",
+	"ask.note":          "Masukkan catatan (opsional, atau ketik '-'):",
+	"ask.note_edit":     "Masukkan catatan:",
+	"ask.quiet_mode":    "Apa yang terjadi dengan pengingat yang jatuh di jam tenang?",
+	"ask.duration":      "Berapa lama acaranya? Ketik durasi (mis. 90 menit, 1,5 jam) atau jam selesai (mis. 10:30), atau pilih 'Tanpa durasi':",
+	"ask.tags":          "Masukkan tag, pisahkan dengan koma (mis. kuliah, kerja), pilih tag yang sudah ada, atau ketik '-':",
+	"ask.reminder_type": "Pilih tipe reminder:",
+	"ask.field":         "Pilih field yang ingin diubah:",
+	"ask.new_value":     "Masukkan nilai baru untuk %s:",
+	"ask.pick_value":    "Pilih nilai baru untuk %s:",
+	"ask.edit_title":    "Masukkan judul jadwal yang ingin diubah:",
+	"ask.delete_title":  "Masukkan judul jadwal yang ingin dihapus:",
+	"ask.edit_continue": "Ingin melanjutkan edit field lain?",
+
+	"field.title":    "judul",
+	"field.time":     "waktu",
+	"field.days":     "hari",
+	"field.note":     "catatan",
+	"field.tags":     "tag",
+	"field.duration": "durasi",
+	"field.quiet":    "jam tenang",
+
+	"title.exists":       "❌ Judul sudah ada. Gunakan judul yang berbeda.",
+	"title.exists_named": "❌ Judul %s sudah ada. Gunakan judul yang berbeda.",
+	"days.min_one":       "Pilih minimal satu hari!",
+	"days.invalid":       "Format hari tidak valid.",
+	"days.invalid_pick":  "Format hari tidak valid. Pilih dari tombol yang tersedia.",
+	"days.invalid_hint":  "Format hari tidak valid. Contoh: Senin, Rabu",
+	"days.already":       "Hari sudah dipilih. Pilih hari lain atau tekan %s",
+	"days.selected":      "Hari yang dipilih: %s",
+	"days.picked":        "✅ %s dipilih",
+
+	"schedule.created":      "✅ <b>Jadwal berhasil ditambahkan!</b>\n📌 %s\n⏰ Reminder: %s (1h, 30m, 5m sebelum waktu yang ditentukan)",
+	"field.updated":         "✅ %s berhasil diperbarui!",
+	"edit.done":             "Perubahan jadwal selesai. Ketik /help untuk bantuan.",
+	"schedule.deleted":      "✅ Jadwal berhasil dihapus!",
+	"delete.failed":         "Gagal menghapus jadwal.",
+	"note.none":             "(tanpa catatan)",
+	"notify.main":           "🔔 <b>WAKTUNYA SEKARANG!</b>\n📌 %s\n⏰ Waktu: %s\n📝 %s",
+	"notify.reminder.other": "⏰ <b>Pengingat %d menit sebelum:</b>\n📌 %s\n📝 %s\n⏰ Waktu: %s",
+
+	"time.invalid":       "Format waktu tidak valid. Contoh: 09:30, 9.30, 21.15 atau 9:30 pm",
+	"time.type_or_pick":  "Ketik waktu (contoh: 09:30, 9.30, 9:30 pm) atau pilih di bawah.",
+	"time.pick_hour":     "🕐 Pilih jam:",
+	"time.pick_minute":   "🕐 Jam %s, pilih menit:",
+	"time.picked":        "⏰ Waktu dipilih: %s",
+	"time.picker_closed": "Pilihan waktu ini sudah tidak berlaku.",
+
+	"draft.header":     "🧠 <b>Jadwal yang saya pahami:</b>\n\n",
+	"draft.confirm":    "Simpan jadwal ini?",
+	"draft.ask_time":   "Jam berapa?",
+	"draft.ask_days":   "Hari apa saja? Ketik hari (contoh: Senin, Rabu atau setiap hari kerja):",
+	"draft.many_times": "⚠️ Satu jadwal hanya punya satu jam, tapi saya menemukan %s. Buat jadwal terpisah untuk jam lainnya.",
+	"draft.past":       "⏰ Jam %s hari ini sudah lewat.",
+	"draft.too_far":    "📅 %s belum bisa dijadwalkan: pengingat sekali berbunyi pada hari terdekat yang dipilih.",
+
+	"export.caption.other": "📤 %d jadwal diekspor. Buka file ini untuk menambahkannya ke aplikasi kalender Anda.",
+	"export.failed":        "❌ Gagal membuat file kalender.",
+	"export.send_failed":   "❌ Gagal mengirim file kalender.",
+
+	"feed.disabled": "Fitur langganan kalender belum diaktifkan oleh admin bot.",
+	"feed.failed":   "❌ Gagal membuat link kalender.",
+	"feed.link":     "📅 <b>Link langganan kalender Anda:</b>\n%s",
+	"feed.webcal":   "\n\nBuka langsung di aplikasi kalender:\nwebcal://%s",
+	"feed.howto":    "\n\nTambahkan link ini sebagai kalender langganan (subscribe). Perubahan dari /add dan /edit akan ikut tersinkron.",
+	"feed.reset":    "🔄 Link lama sudah tidak berlaku.\n\n",
+	"feed.warning":  "\n\n⚠️ Jangan bagikan link ini. Ketik /feed reset untuk membuat link baru.",
+
+	"import.only_ics":        "Hanya file kalender .ics yang bisa diimpor.",
+	"import.too_large":       "❌ File terlalu besar (maksimal 1 MB).",
+	"import.download_failed": "❌ Gagal mengunduh file.",
+	"import.nothing":         "Tidak ada acara yang bisa diimpor dari file ini.",
+	"import.not_calendar":    "❌ File ini bukan kalender iCalendar yang valid.",
+	"import.read_failed":     "❌ Gagal membaca file.",
+	"import.skip.no_start":   "tidak ada waktu mulai",
+	"import.skip.all_day":    "acara seharian tidak memiliki jam",
+	"import.skip.bad_time":   "waktu <code>%s</code> tidak valid",
+	"import.skip.past":       "acara sudah lewat",
+	"import.skip.too_far":    "acara sekali lebih dari seminggu lagi",
+	"import.skip.interval":   "pengulangan tiap %s periode belum didukung",
+	"import.skip.count":      "pengulangan yang berhenti setelah %s kali belum didukung",
+	"import.skip.until":      "pengulangan yang berakhir %s belum didukung",
+	"import.skip.ended":      "pengulangan sudah berakhir",
+	"import.skip.byday":      "hari <code>%s</code> tidak valid",
+	"import.skip.frequency":  "pengulangan <code>%s</code> belum didukung",
+	"import.found.other":     "📥 <b>%d jadwal ditemukan:</b>\n\n",
+	"import.title_exists":    "   ⚠️ Judul sudah ada, akan dilewati\n",
+	"import.not_imported":    "\nTidak diimpor:\n",
+	"import.all_exist":       "\nSemua judul sudah ada. Tidak ada jadwal baru untuk disimpan.",
+	"import.save.other":      "\nSimpan %d jadwal?",
+	"import.confirm":         "Simpan jadwal dari file?",
+	"import.saved.other":     "✅ %d jadwal berhasil diimpor!",
+	"import.skipped.other":   "\n⏭️ %d jadwal dilewati.",
+
+	"agenda.today":          "📅 <b>Agenda hari ini</b>, %s\n",
+	"agenda.tomorrow":       "📅 <b>Agenda besok</b>, %s\n",
+	"agenda.week":           "📅 <b>Agenda 7 hari ke depan</b>\n",
+	"agenda.next.other":     "⏭️ <b>%d jadwal berikutnya:</b>\n",
+	"agenda.empty.today":    "Tidak ada jadwal hari ini. 🎉",
+	"agenda.empty.tomorrow": "Tidak ada jadwal besok. 🎉",
+	"agenda.empty.week":     "Tidak ada jadwal dalam 7 hari ke depan.",
+	"agenda.empty.next":     "Tidak ada jadwal yang akan datang.",
+	"agenda.next.invalid":   "Jumlah tidak valid. Contoh: /next 5",
+	"agenda.in":             "dalam %s",
+	"agenda.soon":           "sebentar lagi",
+	"agenda.past":           "sudah lewat",
+
+	"list.header":            "📅 <b>Jadwal Anda (%d)</b> • urut %s • halaman %d/%d\n",
+	"list.sort.next":         "waktu berikutnya",
+	"list.sort.title":        "judul",
+	"list.sort.created":      "terbaru dibuat",
+	"list.sort.invalid":      "Urutan tidak dikenal. Pilihan: /list next, /list title, /list created.",
+	"list.button.next":       "🕐 Waktu",
+	"list.button.title":      "🔤 Judul",
+	"list.button.created":    "🆕 Terbaru",
+	"list.paused":            "⏸️ Dijeda",
+	"list.paused_until":      "⏸️ Dijeda sampai %s",
+	"list.skipped":           "🚫 Dilewati: %s",
+	"list.holidays":          "🎌 Libur: %s",
+	"list.priority":          "🚨 Prioritas: diulang tiap %d menit, maks. %d kali",
+	"list.quiet":             "🌙 Jam tenang: %s",
+	"list.created_by":        "👤 Ditambahkan oleh %s",
+	"list.subscribers.other": "👥 %d pelanggan",
+	"list.legend":            "✏️ ubah • 🗑️ hapus • ⏸️ jeda / ▶️ lanjutkan • 📄 duplikat",
+	"list.delete_confirm":    "Hapus jadwal %s?",
+	"list.gone":              "Jadwal tersebut sudah tidak ada.",
+	"list.editing":           "✏️ Mengubah %s.",
+	"list.copy_title":        "%s (salinan)",
+	"list.copy_title_n":      "%s (salinan %d)",
+
+	"pause.usage":        "Ketik /pause &lt;judul&gt; untuk menjeda jadwal, atau /pause &lt;judul&gt; sampai &lt;tanggal&gt; untuk menjeda sementara.\nContoh: /pause Rapat Tim sampai 2 November",
+	"pause.done":         "⏸️ %s dijeda. Ketik /resume %s untuk melanjutkannya.",
+	"pause.until":        "⏸️ %s dijeda dan berjalan lagi mulai %s.",
+	"pause.bad_date":     "Tanggal tidak dikenali. Contoh: 2 November, 02/11 atau 2026-11-02.",
+	"pause.past_date":    "Tanggal lanjut harus setelah hari ini.",
+	"resume.usage":       "Ketik /resume &lt;judul&gt; untuk melanjutkan jadwal yang dijeda.",
+	"resume.done":        "▶️ %s berjalan lagi.",
+	"resume.next":        "⏭️ Berikutnya: %s",
+	"resume.not_paused":  "%s tidak sedang dijeda.",
+	"skip.usage":         "Ketik /skip &lt;judul&gt; untuk melewati jadwal berikutnya saja, atau /skip &lt;judul&gt; &lt;tanggal&gt; untuk melewati tanggal tertentu.\nContoh: /skip Kuliah 25 Desember",
+	"skip.done":          "⏭️ %s pada %s dilewati.",
+	"skip.none":          "%s tidak punya jadwal berikutnya untuk dilewati.",
+	"skip.once":          "Jadwal sekali jalan tidak bisa dilewati. Gunakan /delete untuk menghapusnya.",
+	"skip.past_date":     "Tanggal yang dilewati harus setelah sekarang.",
+	"skip.not_scheduled": "%s tidak berjalan pada %s.",
+
+	"holiday.header":       "🎌 <b>Kalender libur</b>\n",
+	"holiday.next":         "berikutnya: %s, %s",
+	"holiday.usage":        "Ketik /holiday &lt;judul&gt; &lt;kalender&gt; agar jadwal tidak berjalan pada hari libur kalender tersebut. Ketik lagi untuk membatalkannya.\nContoh: /holiday Kuliah id",
+	"holiday.no_calendars": "Belum ada kalender libur. Admin bot bisa menaruh file .ics atau .json di direktori HOLIDAYS_DIR.",
+	"holiday.unknown":      "Kalender libur %s tidak ada. Pilihan: %s",
+	"holiday.once":         "Jadwal sekali jalan tidak bisa mengikuti kalender libur.",
+	"holiday.added":        "🎌 %s tidak akan berjalan pada hari libur %s.",
+	"holiday.removed":      "%s kembali berjalan pada hari libur %s.",
+	"holiday.skipped":      "dilewati: libur %s",
+
+	"digest.daily.other":   "🌅 <b>Selamat pagi!</b> Agenda %s (%d jadwal)\n",
+	"digest.weekly.other":  "🗓️ <b>Ringkasan minggu depan</b> mulai %s (%d jadwal)\n",
+	"digest.settings":      "📬 <b>Ringkasan jadwal</b>\n\n🌅 Harian: %s\n🗓️ Mingguan (Minggu 19:00): %s\n\nRingkasan harian berisi semua jadwal hari itu; ringkasan mingguan berisi jadwal Senin sampai Minggu berikutnya. Hari tanpa jadwal tidak dikirimi pesan.",
+	"digest.off":           "nonaktif",
+	"digest.on":            "aktif",
+	"digest.on_at":         "aktif, jam %s",
+	"digest.ask_time":      "Jam berapa ringkasan harian dikirim?",
+	"digest.time_saved":    "🌅 Ringkasan harian dikirim setiap hari jam %s.",
+	"digest.button.daily":  "Harian",
+	"digest.button.weekly": "Mingguan",
+	"digest.button.time":   "⏰ Ubah jam",
+
+	"settings.header":            "⚙️ <b>Pengaturan</b>\n\n🌐 Bahasa: %s\n🕐 Zona waktu: %s\n🔔 Pengingat: %s sebelum\n🔁 Tipe jadwal dari kalimat: %s\n🌙 Jam tenang: %s\n🕘 Jam kerja: %s\n🌅 Ringkasan harian: %s\n🗓️ Ringkasan mingguan: %s\n\nPengingat dan tipe default dipakai untuk jadwal baru.",
+	"settings.default":           "(default)",
+	"settings.ask.timezone":      "Zona waktu apa yang dipakai? Ketik nama seperti Asia/Makassar atau pilih WIB, WITA, WIT.",
+	"settings.ask.reminders":     "Berapa lama sebelum acara pengingat dikirim? Pisahkan dengan koma, misalnya 60, 30, 5 atau 1 jam, 15 menit.",
+	"settings.ask.type":          "Tipe apa yang dipakai jika kalimat tidak menyebutkan sekali atau berulang?",
+	"settings.ask.quiet":         "Di jam berapa pengingat tidak boleh berbunyi? Contoh 22:00-06:00.",
+	"settings.ask.workhours":     "Jam kerja untuk /free? Contoh 08:00-17:00.",
+	"settings.reminders_invalid": "Waktu pengingat tidak valid. Contoh: 60, 30, 5 (maksimal 7 hari).",
+	"settings.saved":             "✅ Pengaturan disimpan.",
+	"settings.button.language":   "🌐 Bahasa",
+	"settings.button.timezone":   "🕐 Zona waktu",
+	"settings.button.reminders":  "🔔 Pengingat",
+	"settings.button.type":       "🔁 Tipe default",
+	"settings.button.quiet":      "🌙 Jam tenang",
+	"settings.button.workhours":  "🕘 Jam kerja",
+	"settings.button.digest":     "🌅 Ringkasan",
+
+	"nag.repeat":         "🚨 <b>BELUM SELESAI:</b> %s (%s)\nPengulangan %d dari %d. Tekan ✅ Selesai jika sudah.",
+	"nag.done":           "✅ %s ditandai selesai oleh %s.",
+	"habit.streak.other": "🔥 %d kali berturut-turut!",
+	"stats.header":       "📊 <b>Statistik kebiasaan</b>",
+	"stats.line":         "🔥 %d beruntun • 🏆 terpanjang %d • ✅ %d/%d (%d%%)",
+	"stats.detail":       "📊 <b>%s</b>\n🔥 Beruntun: %d • 🏆 Terpanjang: %d\n✅ Selesai %d dari %d (%d%%)",
+	"stats.legend":       "Kolom Senin → Minggu\n✅ selesai • ❌ terlewat • ⏳ belum selesai hari ini • ➖ dilewati/dijeda • ▫️ akan datang • ⬜ tidak terjadwal",
+	"stats.hint":         "Tekan ✅ Selesai pada notifikasi untuk mencatat. Ketik /stats &lt;judul&gt; untuk heatmap mingguan.",
+	"stats.empty":        "Belum ada jadwal berulang untuk dihitung.",
+	"stats.once":         "Statistik hanya tersedia untuk jadwal berulang.",
+	"priority.usage":     "Pakai: /priority &lt;judul&gt; [menit] [maks]\nNotifikasi utama jadwal prioritas diulang tiap beberapa menit (default 5) sampai tombol ✅ Selesai ditekan, maksimal beberapa kali (default 6).\n\nContoh: /priority Minum Obat 10 3\nMatikan: /priority Minum Obat off",
+	"priority.on":        "🚨 %s sekarang prioritas: notifikasi diulang tiap %d menit, maks. %d kali, sampai ditandai selesai.",
+	"priority.off":       "%s bukan jadwal prioritas lagi.",
+
+	"duplicate.usage":     "Ketik /duplicate &lt;judul&gt; untuk menyalin jadwal dengan judul baru.\nContoh: /duplicate Kalkulus",
+	"duplicate.ask_title": "Masukkan judul untuk salinan %s:",
+	"duplicate.ask_edit":  "Ubah sesuatu pada %s sekarang, misalnya waktu atau hari?",
+	"template.header":     "📋 <b>Template (%d)</b>\n",
+	"template.none":       "Belum ada template.\n\nSimpan jadwal sebagai template dengan /template &lt;judul&gt;, lalu buat jadwal serupa dengan sekali tekan.",
+	"template.legend":     "➕ buat jadwal • 🗑️ hapus\nSimpan template baru dengan /template &lt;judul&gt;.",
+	"template.ask_title":  "Masukkan pola judul template dari %s. {n} diganti nomor urut, misalnya <code>Kuliah {n}</code> menjadi Kuliah 1, Kuliah 2, dan seterusnya:",
+	"template.saved":      "📋 Template %s disimpan. Ketik /template untuk membuat jadwal darinya.",
+	"template.gone":       "Template tersebut sudah tidak ada.",
+
+	"bulk.header":               "☑️ <b>Aksi massal</b> • %d dari %d jadwal dipilih\nTandai jadwal, lalu pilih aksi di bawah.",
+	"bulk.button.all":           "☑️ Pilih semua",
+	"bulk.button.none":          "⬜ Kosongkan",
+	"bulk.button.del":           "🗑️ Hapus",
+	"bulk.button.pause":         "⏸️ Jeda",
+	"bulk.button.resume":        "▶️ Lanjutkan",
+	"bulk.button.tag":           "🏷️ Ganti tag",
+	"bulk.button.shift":         "🕐 Geser waktu",
+	"bulk.button.type":          "🔁 Tipe",
+	"bulk.button.notag":         "🚫 Tanpa tag",
+	"bulk.button.undo":          "⏪ Urungkan",
+	"bulk.delete_confirm.other": "Hapus %d jadwal berikut?",
+	"bulk.ask_type.other":       "Ubah tipe pengingat %d jadwal menjadi:",
+	"bulk.ask_tag.other":        "Ketik tag baru untuk %d jadwal, misalnya <code>#kuliah #semester1</code>. Tag lama diganti.",
+	"bulk.ask_shift.other":      "Geser waktu %d jadwal berapa menit? Pilih di bawah atau ketik, misalnya <code>+45</code>, <code>-90</code> atau <code>+1 jam</code>.",
+	"bulk.tag_invalid":          "Ketik minimal satu tag, misalnya #kuliah, atau tekan 🚫 Tanpa tag.",
+	"bulk.shift_invalid":        "Ketik pergeseran seperti +30, -15 atau +1 jam (kurang dari 24 jam).",
+	"bulk.no_tags":              "tanpa tag",
+	"bulk.none_selected":        "Tandai minimal satu jadwal terlebih dahulu.",
+	"bulk.expired":              "Pilihan ini sudah tidak berlaku. Ketik /bulk untuk memulai lagi.",
+	"bulk.use_buttons":          "Gunakan tombol pada daftar untuk memilih jadwal, atau ketik /bulk untuk memulai lagi.",
+	"bulk.done.del.other":       "🗑️ %d jadwal dihapus:",
+	"bulk.done.pause.other":     "⏸️ %d jadwal dijeda:",
+	"bulk.done.resume.other":    "▶️ %d jadwal dilanjutkan:",
+	"bulk.done.tag.other":       "🏷️ Tag %d jadwal diganti menjadi %s:",
+	"bulk.done.shift.other":     "🕐 Waktu %d jadwal digeser %s:",
+	"bulk.done.type.other":      "🔁 %d jadwal diubah menjadi %s:",
+	"bulk.undone.other":         "⏪ Perubahan pada %d jadwal diurungkan:",
+	"bulk.undo_expired":         "Aksi ini sudah tidak bisa diurungkan.",
+
+	"quiet.off":         "tidak diatur",
+	"quiet.mode.silent": "dikirim tanpa suara",
+	"quiet.mode.delay":  "ditunda sampai jam tenang selesai",
+	"quiet.mode.skip":   "tidak dikirim",
+	"quiet.usage":       "🌙 Jam tenang chat ini: %s (atur lewat /settings).\n\nPengingat yang jatuh di jam tenang bisa:\n• <code>silent</code> — dikirim tanpa suara (default)\n• <code>delay</code> — ditunda sampai jam tenang selesai, atau dibatalkan jika acara sudah mulai\n• <code>skip</code> — tidak dikirim\n\nPengulangan jadwal prioritas mengikuti mode yang sama; yang ditunda atau dilewati tidak dikirim, pengulangan berikutnya tetap berjalan.\n\nContoh: /quiet Olahraga Pagi delay",
+	"quiet.usage_mode":  "Mode tidak dikenal. Pilihan: silent, delay, skip.",
+	"quiet.saved":       "🌙 Pengingat %s di jam tenang sekarang %s.",
+
+	"duration.invalid":       "Durasi tidak valid. Contoh: 45 menit, 1 jam 30 menit, atau jam selesai 10:30. Acara harus selesai sebelum tengah malam.",
+	"duration.past_midnight": "Dengan durasi %s acara ini akan lewat tengah malam. Pilih jam yang lebih awal atau ubah durasinya dulu.",
+	"conflict.header.other":  "⚠️ %s bentrok dengan %d jadwal lain:",
+	"free.usage":             "Ketik /free &lt;hari&gt; untuk melihat waktu luang, mis. /free senin, /free besok atau /free jumat 13:00-17:00.",
+	"free.header":            "🟢 <b>Waktu luang</b> %s • %s\n",
+	"free.none":              "Tidak ada waktu luang pada jam kerja ini.",
+	"workhours.current":      "🕘 Jam kerja untuk /free: %s\nUbah dengan /workhours 09:00-18:00 atau kembalikan dengan /workhours reset.",
+	"workhours.saved":        "🕘 Jam kerja untuk /free sekarang %s.",
+
+	"tag.filter":     "🏷️ Filter: #%s\n",
+	"tag.none":       "Tidak ada jadwal dengan tag #%s.",
+	"tag.mute_usage": "Ketik /mute #tag untuk membisukan semua pengingat dengan tag tersebut, dan /unmute #tag untuk mengaktifkannya lagi.",
+	"tag.muted_list": "🔕 Tag yang dibisukan: %s",
+	"tag.muted":      "🔕 Pengingat dengan tag %s dibisukan.",
+	"tag.unmuted":    "🔔 Pengingat dengan tag %s aktif lagi.",
+
+	"share.usage":         "Ketik /share &lt;judul&gt; untuk membuat link undangan, /share &lt;judul&gt; @username untuk mengundang langsung, atau /share &lt;judul&gt; reset untuk mengganti link.",
+	"share.link":          "🔗 <b>Link undangan untuk</b> %s:\n%s\n\nSiapa pun yang membuka link ini bisa menerima pengingat yang sama. Perubahan yang Anda buat ikut berlaku untuk mereka.",
+	"share.unknown_user":  "%s belum pernah memulai bot ini, jadi undangan tidak bisa dikirim langsung. Kirimkan link ini kepadanya:\n%s",
+	"share.already_named": "%s sudah menerima pengingat %s.",
+	"share.sent":          "✅ Undangan %s dikirim ke %s.",
+	"share.invalid":       "❌ Link undangan tidak valid atau jadwalnya sudah dihapus.",
+	"share.own":           "Ini jadwal Anda sendiri.",
+	"share.already":       "Anda sudah berlangganan jadwal %s. Lihat /shared.",
+	"share.invite":        "📨 <b>Undangan jadwal</b>\n📌 %s\n⏰ %s • 📆 %s",
+	"share.invite_hint":   "Terima untuk mendapat pengingat yang sama dengan pemiliknya.",
+	"share.accepted":      "✅ Anda berlangganan %s. Bisukan atau berhenti kapan saja lewat /shared.",
+	"share.declined":      "Undangan ditolak.",
+	"share.joined":        "👥 %s berlangganan jadwal %s.",
+	"share.updated":       "✏️ Jadwal %s diubah oleh pemiliknya. Lihat /shared.",
+	"share.deleted":       "🗑️ Jadwal %s dihapus oleh pemiliknya. Anda tidak akan menerima pengingatnya lagi.",
+	"share.reset":         "🔄 Link dan undangan lama sudah tidak berlaku. Pelanggan yang sudah ada tetap menerima pengingat.\n\n",
+	"share.reset_hint":    "\n\nKetik <code>/share %s reset</code> untuk mengganti link ini.",
+	"shared.header":       "👥 <b>Jadwal yang dibagikan ke Anda (%d)</b>\n",
+	"shared.none":         "Belum ada jadwal yang dibagikan ke Anda.",
+	"shared.from":         "👤 Dari %s",
+	"shared.muted":        "🔕 Dibisukan",
+	"rsvp.yes":            "✅ Hadir",
+	"rsvp.no":             "❌ Tidak",
+	"rsvp.maybe":          "🤔 Mungkin",
+	"rsvp.usage":          "Ketik /rsvp &lt;judul&gt; untuk melihat siapa saja yang hadir.",
+	"rsvp.none":           "Belum ada jawaban kehadiran untuk %s. Tombol kehadiran muncul di pengingat jadwal grup atau jadwal yang dibagikan.",
+	"rsvp.header":         "📋 <b>Kehadiran</b> %s\n📆 %s • ⏰ %s\n",
+	"shared.legend":       "🔕 bisukan / 🔔 aktifkan • 🚪 berhenti berlangganan",
+
+	"unit.day.other":    "%d hari",
+	"unit.hour.other":   "%d jam",
+	"unit.minute.other": "%d menit",
+
+	"button.cancel":         "❌ Batal",
+	"button.default":        "↩️ Default",
+	"button.days_done":      "🔄 Selesai Pilih",
+	"button.no_note":        "Tidak ada catatan",
+	"button.no_tags":        "Tanpa tag",
+	"button.no_duration":    "Tanpa durasi",
+	"button.once":           "🔔 Sekali",
+	"button.recurring":      "🔊 Berkali-kali",
+	"button.field.title":    "1️⃣ Title",
+	"button.field.time":     "2️⃣ Waktu",
+	"button.field.days":     "3️⃣ Hari",
+	"button.field.note":     "4️⃣ Catatan",
+	"button.field.type":     "5️⃣ Tipe",
+	"button.field.tags":     "🏷️ Tag",
+	"button.field.duration": "⏱️ Durasi",
+	"button.field.quiet":    "🌙 Jam Tenang",
+	"button.quiet.silent":   "🔕 Kirim tanpa suara",
+	"button.quiet.delay":    "⏳ Tunda",
+	"button.quiet.skip":     "🚫 Lewati",
+	"button.edit_more":      "✏️ Lanjut Edit",
+	"button.done":           "✅ Selesai",
+	"button.save":           "✅ Simpan",
+	"button.edit":           "✏️ Ubah",
+	"button.save_all":       "✅ Simpan Semua",
+	"button.delete_yes":     "🗑️ Ya, hapus",
+	"button.back":           "↩️ Kembali",
+	"button.accept":         "✅ Terima",
+	"button.decline":        "❌ Tolak",
+	"button.change_hour":    "⬅️ Ganti jam",
+
+	"day.Sunday":    "Minggu",
+	"day.Monday":    "Senin",
+	"day.Tuesday":   "Selasa",
+	"day.Wednesday": "Rabu",
+	"day.Thursday":  "Kamis",
+	"day.Friday":    "Jumat",
+	"day.Saturday":  "Sabtu",
+
+	"day_button.Sunday":    "Minggu (Sunday)",
+	"day_button.Monday":    "Senin (Monday)",
+	"day_button.Tuesday":   "Selasa (Tuesday)",
+	"day_button.Wednesday": "Rabu (Wednesday)",
+	"day_button.Thursday":  "Kamis (Thursday)",
+	"day_button.Friday":    "Jumat (Friday)",
+	"day_button.Saturday":  "Sabtu (Saturday)",
+
+	"month.January":   "Januari",
+	"month.February":  "Februari",
+	"month.March":     "Maret",
+	"month.April":     "April",
+	"month.May":       "Mei",
+	"month.June":      "Juni",
+	"month.July":      "Juli",
+	"month.August":    "Agustus",
+	"month.September": "September",
+	"month.October":   "Oktober",
+	"month.November":  "November",
+	"month.December":  "Desember",
+}
+
+const helpID = `🤖 <b>Schedule Bot - Bantuan</b>
+
+Perintah yang tersedia:
+/add - Tambah jadwal baru
+/list - Lihat semua jadwal (/list title atau /list created untuk urutan lain, /list #kuliah per tag)
+/today - Agenda hari ini (/today #kerja per tag)
+/tomorrow - Agenda besok
+/week - Agenda 7 hari ke depan
+/next - Jadwal berikutnya (/next 10 untuk 10 jadwal)
+/edit - Ubah jadwal
+/delete - Hapus jadwal
+/export - Ekspor jadwal ke kalender (.ics, /export #kuliah per tag)
+/feed - Link langganan kalender (/feed reset untuk ganti link)
+/share - Bagikan jadwal (/share &lt;judul&gt;, /share &lt;judul&gt; @username atau /share &lt;judul&gt; reset)
+/shared - Jadwal yang dibagikan ke Anda
+/pause - Jeda jadwal (/pause &lt;judul&gt; sampai 2 November)
+/resume - Lanjutkan jadwal yang dijeda
+/skip - Lewati jadwal berikutnya atau tanggal tertentu (/skip Kuliah 25 Desember)
+/holiday - Lewati hari libur nasional (/holiday Kuliah id)
+/digest - Ringkasan jadwal harian & mingguan
+/settings - Pengaturan zona waktu, pengingat & lainnya
+/quiet - Perilaku pengingat di jam tenang
+/priority - Ulangi notifikasi sampai ditandai selesai
+/stats - Statistik & streak kebiasaan
+/bulk - Hapus, jeda, ganti tag atau geser waktu banyak jadwal sekaligus
+/duplicate - Salin jadwal dengan judul baru
+/template - Template jadwal (/template &lt;judul&gt; untuk menyimpan)
+/free - Waktu luang dalam jam kerja (/free senin, /free besok)
+/workhours - Atur jam kerja untuk /free (/workhours 09:00-18:00)
+/mute - Bisukan pengingat per tag (/mute #pribadi, /unmute #pribadi)
+/rsvp - Rekap kehadiran (/rsvp &lt;judul&gt;)
+/language - Ganti bahasa (Language)
+/help - Tampilkan bantuan ini
+
+Contoh penggunaan:
+1. Ketik /add
+2. Ikuti petunjuk untuk membuat jadwal baru
+3. Bot akan mengingatkan Anda sesuai jadwal
+
+Atau tulis langsung dalam satu kalimat:
+/add rapat tim setiap Senin dan Rabu jam 09.30
+minum obat setiap hari jam 7 malam
+call mom tomorrow at 7 pm
+
+Punya jadwal di aplikasi kalender? Kirim file .ics ke bot untuk mengimpornya.
+
+Tambahkan bot ke grup untuk jadwal tim: semua anggota bisa menambah jadwal dan pengingat dikirim ke grup, tetapi hanya admin yang bisa mengubah, menjeda atau menghapusnya.
+
+Untuk pertanyaan, silakan hubungi @FtrRahman`
@@ -0,0 +1,453 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+// dayByCode maps RRULE BYDAY codes back to stored day names.
+var dayByCode = map[string]string{
+	"SU": "Sunday", "MO": "Monday", "TU": "Tuesday", "WE": "Wednesday",
+	"TH": "Thursday", "FR": "Friday", "SA": "Saturday",
+}
+
+// durationPattern matches the dur-value subset used by alarm triggers.
+var durationPattern = regexp.MustCompile(`^([+-]?)P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ErrNotCalendar is returned for files that do not start with a VCALENDAR.
+var ErrNotCalendar = errors.New("file bukan iCalendar yang valid")
+
+// Reason says why an event was skipped by Decode.
+type Reason string
+
+const (
+	ReasonNoStart   Reason = "no_start"  // no DTSTART
+	ReasonAllDay    Reason = "all_day"   // DTSTART is a date without time
+	ReasonBadTime   Reason = "bad_time"  // Value is the unparsable time
+	ReasonPast      Reason = "past"      // single event already over
+	ReasonTooFar    Reason = "too_far"   // single event more than a week away
+	ReasonInterval  Reason = "interval"  // Value is the INTERVAL
+	ReasonCount     Reason = "count"     // Value is the COUNT
+	ReasonUntil     Reason = "until"     // Value is the future UNTIL date
+	ReasonEnded     Reason = "ended"     // UNTIL already passed
+	ReasonByDay     Reason = "byday"     // Value is the invalid BYDAY code
+	ReasonFrequency Reason = "frequency" // Value is the FREQ, lower case
+)
+
+// SkipError explains why the event titled Title was not converted. Value
+// holds the offending part of the event for the reasons that have one.
+type SkipError struct {
+	Title  string
+	Reason Reason
+	Value  string
+}
+
+func (e *SkipError) Error() string {
+	var why string
+	switch e.Reason {
+	case ReasonNoStart:
+		why = "tidak ada DTSTART"
+	case ReasonAllDay:
+		why = "acara seharian tidak memiliki jam"
+	case ReasonBadTime:
+		why = fmt.Sprintf("waktu '%s' tidak valid", e.Value)
+	case ReasonPast:
+		why = "acara sudah lewat"
+	case ReasonTooFar:
+		why = "acara sekali lebih dari seminggu lagi"
+	case ReasonInterval:
+		why = fmt.Sprintf("pengulangan tiap %s periode belum didukung", e.Value)
+	case ReasonCount:
+		why = fmt.Sprintf("pengulangan yang berhenti setelah %s kali belum didukung", e.Value)
+	case ReasonUntil:
+		why = fmt.Sprintf("pengulangan yang berakhir %s belum didukung", e.Value)
+	case ReasonEnded:
+		why = "pengulangan sudah berakhir"
+	case ReasonByDay:
+		why = fmt.Sprintf("BYDAY '%s' tidak valid", e.Value)
+	case ReasonFrequency:
+		why = fmt.Sprintf("pengulangan %s belum didukung", e.Value)
+	default:
+		why = string(e.Reason)
+	}
+	return fmt.Sprintf("%q dilewati: %s", e.Title, why)
+}
+
+// Decode reads VEVENTs from an iCalendar stream and converts them into
+// schedule drafts evaluated in loc. Weekly and daily RRULEs become
+// recurring schedules, single events in the future become "once" schedules
+// and VALARMs that go off before the event become reminder offsets. Events
+// that cannot be represented are skipped and explained in the returned
+// SkipErrors. The drafts have no ID or UserID yet. A file that is not an
+// iCalendar yields ErrNotCalendar.
+func Decode(r io.Reader, loc *time.Location, now time.Time) ([]*storage.Schedule, []*SkipError, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		schedules []*storage.Schedule
+		warnings  []*SkipError
+		event     *vevent
+		inAlarm   bool
+	)
+	for _, l := range lines {
+		name, params, value := parseLine(l)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &vevent{params: make(map[string]map[string]string), props: make(map[string]string)}
+		case name == "END" && value == "VEVENT" && event != nil:
+			s, err := event.schedule(loc, now)
+			if err != nil {
+				warnings = append(warnings, err)
+			} else {
+				schedules = append(schedules, s)
+			}
+			event = nil
+		case event == nil:
+			continue
+		case name == "BEGIN" && value == "VALARM":
+			inAlarm = true
+		case name == "END" && value == "VALARM":
+			inAlarm = false
+		case inAlarm:
+			if name == "TRIGGER" && params["VALUE"] != "DATE-TIME" {
+				if minutes, ok := parseTrigger(value); ok {
+					event.alarms = append(event.alarms, alarm{before: minutes, fromEnd: params["RELATED"] == "END"})
+				}
+			}
+		default:
+			if _, seen := event.props[name]; !seen {
+				event.props[name] = value
+				event.params[name] = params
+			}
+		}
+	}
+
+	return schedules, warnings, nil
+}
+
+type vevent struct {
+	props  map[string]string
+	params map[string]map[string]string
+	alarms []alarm
+}
+
+// alarm is a relative VALARM trigger, going off before minutes before the
+// start of the event or, with fromEnd, before its end.
+type alarm struct {
+	before  int
+	fromEnd bool
+}
+
+func (ev *vevent) schedule(loc *time.Location, now time.Time) (*storage.Schedule, *SkipError) {
+	title := unescapeText(ev.props["SUMMARY"])
+	if strings.TrimSpace(title) == "" {
+		title = "(tanpa judul)"
+	}
+	skip := func(reason Reason, value string) (*storage.Schedule, *SkipError) {
+		return nil, &SkipError{Title: title, Reason: reason, Value: value}
+	}
+
+	raw, ok := ev.props["DTSTART"]
+	if !ok {
+		return skip(ReasonNoStart, "")
+	}
+	params := ev.params["DTSTART"]
+	if params["VALUE"] == "DATE" || len(raw) == 8 {
+		return skip(ReasonAllDay, "")
+	}
+	start, err := parseDateTime(raw, params["TZID"], loc)
+	if err != nil {
+		return skip(ReasonBadTime, raw)
+	}
+	length := ev.length(start, loc)
+
+	// Weekday shift between the event's own zone and loc, so BYDAY values
+	// land on the day the event actually happens locally.
+	local := start.In(loc)
+	shift := dayIndex(local) - dayIndex(start)
+
+	s := &storage.Schedule{
+		Title:         title,
+		Time:          local.Format("15:04"),
+		Duration:      duration(local, length),
+		Note:          unescapeText(ev.props["DESCRIPTION"]),
+		Tags:          ev.tags(),
+		ReminderSent:  make(map[string]bool),
+		ReminderTimes: ev.reminderTimes(length),
+	}
+
+	rule, recurring := ev.props["RRULE"]
+	if !recurring {
+		if !local.After(now) {
+			return skip(ReasonPast, "")
+		}
+		if local.Sub(now) > 7*24*time.Hour {
+			return skip(ReasonTooFar, "")
+		}
+		s.ReminderType = storage.ReminderOnce
+		s.Days = []string{local.Weekday().String()}
+		return s, nil
+	}
+
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			parts[strings.ToUpper(k)] = strings.ToUpper(v)
+		}
+	}
+	if interval := parts["INTERVAL"]; interval != "" && interval != "1" {
+		return skip(ReasonInterval, interval)
+	}
+	// Schedules repeat until they are deleted, so a recurrence that stops
+	// cannot be represented once it has a future end
+	if count := parts["COUNT"]; count != "" {
+		return skip(ReasonCount, count)
+	}
+	if until := parts["UNTIL"]; until != "" {
+		end, err := parseDateTime(until, params["TZID"], loc)
+		if err != nil {
+			return skip(ReasonBadTime, until)
+		}
+		if end.Before(now) {
+			return skip(ReasonEnded, "")
+		}
+		return skip(ReasonUntil, end.In(loc).Format(storage.DateLayout))
+	}
+
+	s.ReminderType = storage.ReminderRecurring
+	switch parts["FREQ"] {
+	case "DAILY":
+		s.Days = append([]string(nil), storage.Weekdays...)
+	case "WEEKLY":
+		byDay := parts["BYDAY"]
+		if byDay == "" {
+			s.Days = []string{local.Weekday().String()}
+			break
+		}
+		seen := make(map[string]bool)
+		for _, code := range strings.Split(byDay, ",") {
+			// Strip ordinal prefixes such as "1MO", which are meaningless
+			// for weekly rules
+			code = strings.TrimLeft(code, "+-0123456789")
+			day, ok := dayByCode[code]
+			if !ok {
+				return skip(ReasonByDay, code)
+			}
+			wd, _ := storage.ParseWeekday(day)
+			day = time.Weekday((int(wd) + shift + 7) % 7).String()
+			if !seen[day] {
+				seen[day] = true
+				s.Days = append(s.Days, day)
+			}
+		}
+	default:
+		return skip(ReasonFrequency, strings.ToLower(parts["FREQ"]))
+	}
+
+	sortDays(s.Days)
+	return s, nil
+}
+
+// length returns the length of the event in minutes from DURATION or
+// DTEND, or zero when it has none.
+func (ev *vevent) length(start time.Time, loc *time.Location) int {
+	minutes := 0
+	if raw, ok := ev.props["DURATION"]; ok {
+		if m := durationPattern.FindStringSubmatch(strings.TrimSpace(raw)); m != nil && m[1] != "-" {
+			n := func(s string) int {
+				v, _ := strconv.Atoi(s)
+				return v
+			}
+			minutes = n(m[2])*7*24*60 + n(m[3])*24*60 + n(m[4])*60 + n(m[5])
+		}
+	} else if raw, ok := ev.props["DTEND"]; ok {
+		if end, err := parseDateTime(raw, ev.params["DTEND"]["TZID"], loc); err == nil {
+			minutes = int(end.Sub(start) / time.Minute)
+		}
+	}
+	if minutes < 0 {
+		return 0
+	}
+	return minutes
+}
+
+// duration returns the schedule duration of an event of length minutes
+// starting at local, or zero when it does not end on the day it starts.
+func duration(local time.Time, length int) int {
+	if length <= 0 || local.Hour()*60+local.Minute()+length > storage.MinutesPerDay {
+		return 0
+	}
+	return length
+}
+
+// reminderTimes returns the alarm offsets in minutes before the start of an
+// event lasting length minutes, largest first, or the bot defaults when the
+// event has no alarm going off before it starts.
+func (ev *vevent) reminderTimes(length int) []int {
+	seen := make(map[int]bool)
+	var result []int
+	for _, a := range ev.alarms {
+		m := a.before
+		if a.fromEnd {
+			m -= length
+		}
+		if m > 0 && !seen[m] {
+			seen[m] = true
+			result = append(result, m)
+		}
+	}
+	if len(result) == 0 {
+		return append([]int(nil), storage.DefaultReminderTimes...)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(result)))
+	return result
+}
+
+// unfold joins folded content lines and drops empty ones.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca file ics: %w", err)
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrNotCalendar
+	}
+	return lines, nil
+}
+
+// parseLine splits "NAME;PARAM=VALUE:content" into its parts. Colons inside
+// quoted parameter values do not end the name section.
+func parseLine(l string) (string, map[string]string, string) {
+	inQuote := false
+	sep := -1
+	for i, c := range l {
+		if c == '"' {
+			inQuote = !inQuote
+		} else if c == ':' && !inQuote {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return strings.ToUpper(l), nil, ""
+	}
+
+	head, value := l[:sep], l[sep+1:]
+	fields := strings.Split(head, ";")
+	params := make(map[string]string)
+	for _, p := range fields[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(fields[0]), params, value
+}
+
+func parseDateTime(value, tzid string, loc *time.Location) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeFormat+"Z", value)
+	}
+	zone := loc
+	if tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			zone = tz
+		}
+	}
+	if len(value) == 8 {
+		return time.ParseInLocation("20060102", value, zone)
+	}
+	t, err := time.ParseInLocation(dateTimeFormat, value, zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("waktu '%s' tidak valid", value)
+	}
+	return t, nil
+}
+
+// parseTrigger converts a relative trigger such as "-PT15M" into minutes
+// before the time it is related to; triggers after it are negative.
+func parseTrigger(value string) (int, bool) {
+	m := durationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, false
+	}
+	n := func(s string) int {
+		v, _ := strconv.Atoi(s)
+		return v
+	}
+	minutes := n(m[2])*7*24*60 + n(m[3])*24*60 + n(m[4])*60 + n(m[5]) + n(m[6])/60
+	if m[1] != "-" {
+		minutes = -minutes
+	}
+	return minutes, true
+}
+
+// dayIndex counts days since the epoch in t's own location.
+func dayIndex(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+func sortDays(days []string) {
+	sort.Slice(days, func(i, j int) bool {
+		a, _ := storage.ParseWeekday(days[i])
+		b, _ := storage.ParseWeekday(days[j])
+		return a < b
+	})
+}
+
+// tags turns CATEGORIES into schedule tags, dropping categories that are not
+// valid tags such as long phrases.
+func (ev *vevent) tags() []string {
+	var tags []string
+	for _, category := range strings.Split(unescapeText(ev.props["CATEGORIES"]), ",") {
+		category = strings.ReplaceAll(strings.TrimSpace(category), " ", "-")
+		parsed, err := storage.ParseTags(category)
+		if err != nil {
+			continue
+		}
+		for _, tag := range parsed {
+			if !containsTag(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func unescapeText(s string) string {
+	r := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return r.Replace(s)
+}
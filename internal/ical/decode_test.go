@@ -0,0 +1,163 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+// calendar wraps the event lines in a VEVENT inside a VCALENDAR.
+func calendar(lines ...string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nSUMMARY:Acara\r\n" +
+		strings.Join(lines, "\r\n") + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+}
+
+func TestDecode(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	// Monday 19 October 2026, 10:00 WIB
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name      string
+		data      string
+		want      *storage.Schedule
+		reason    Reason
+		value     string
+		reminders []int
+	}{
+		{
+			name: "weekly",
+			data: calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "DURATION:PT1H30M", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE"),
+			want: &storage.Schedule{Time: "09:00", Duration: 90, Days: []string{"Monday", "Wednesday"}, ReminderType: storage.ReminderRecurring},
+		},
+		{
+			name: "daily",
+			data: calendar("DTSTART;TZID=Asia/Jakarta:20261005T063000", "RRULE:FREQ=DAILY"),
+			want: &storage.Schedule{Time: "06:30", Days: storage.Weekdays, ReminderType: storage.ReminderRecurring},
+		},
+		{
+			name: "byday shifted to the local day",
+			data: calendar("DTSTART:20261004T200000Z", "RRULE:FREQ=WEEKLY;BYDAY=SU"),
+			want: &storage.Schedule{Time: "03:00", Days: []string{"Monday"}, ReminderType: storage.ReminderRecurring},
+		},
+		{
+			name: "once",
+			data: calendar("DTSTART;TZID=Asia/Jakarta:20261022T163000", "DTEND;TZID=Asia/Jakarta:20261022T170000"),
+			want: &storage.Schedule{Time: "16:30", Duration: 30, Days: []string{"Thursday"}, ReminderType: storage.ReminderOnce},
+		},
+		{
+			name: "alarms",
+			data: calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "DURATION:PT1H", "RRULE:FREQ=WEEKLY",
+				"BEGIN:VALARM", "TRIGGER:-PT15M", "END:VALARM",
+				"BEGIN:VALARM", "TRIGGER:-P1D", "END:VALARM",
+				"BEGIN:VALARM", "TRIGGER:PT5M", "END:VALARM",
+				"BEGIN:VALARM", "TRIGGER;RELATED=END:-PT90M", "END:VALARM",
+				"BEGIN:VALARM", "TRIGGER;RELATED=END:-PT15M", "END:VALARM"),
+			want:      &storage.Schedule{Time: "09:00", Duration: 60, Days: []string{"Monday"}, ReminderType: storage.ReminderRecurring},
+			reminders: []int{1440, 30, 15},
+		},
+		{
+			name:   "no start",
+			data:   calendar("DURATION:PT1H"),
+			reason: ReasonNoStart,
+		},
+		{
+			name:   "all day",
+			data:   calendar("DTSTART;VALUE=DATE:20261020"),
+			reason: ReasonAllDay,
+		},
+		{
+			name:   "bad time",
+			data:   calendar("DTSTART:2026-10-20 09:00"),
+			reason: ReasonBadTime,
+			value:  "2026-10-20 09:00",
+		},
+		{
+			name:   "past",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261019T090000"),
+			reason: ReasonPast,
+		},
+		{
+			name:   "too far",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261105T090000"),
+			reason: ReasonTooFar,
+		},
+		{
+			name:   "interval",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;INTERVAL=2"),
+			reason: ReasonInterval,
+			value:  "2",
+		},
+		{
+			name:   "count",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;COUNT=10"),
+			reason: ReasonCount,
+			value:  "10",
+		},
+		{
+			name:   "future until",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;UNTIL=20261214T020000Z"),
+			reason: ReasonUntil,
+			value:  "2026-12-14",
+		},
+		{
+			name:   "ended",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20260105T090000", "RRULE:FREQ=WEEKLY;UNTIL=20260601T020000Z"),
+			reason: ReasonEnded,
+		},
+		{
+			name:   "bad byday",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;BYDAY=XX"),
+			reason: ReasonByDay,
+			value:  "XX",
+		},
+		{
+			name:   "monthly",
+			data:   calendar("DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=MONTHLY"),
+			reason: ReasonFrequency,
+			value:  "monthly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedules, skipped, err := Decode(strings.NewReader(tt.data), jakarta, now)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if tt.want == nil {
+				if len(schedules) != 0 || len(skipped) != 1 {
+					t.Fatalf("got %d schedules and %d skipped, want one skipped", len(schedules), len(skipped))
+				}
+				if e := skipped[0]; e.Title != "Acara" || e.Reason != tt.reason || e.Value != tt.value {
+					t.Errorf("skipped %+v, want reason %s value %q", e, tt.reason, tt.value)
+				}
+				return
+			}
+
+			if len(schedules) != 1 {
+				t.Fatalf("got %d schedules, skipped %v", len(schedules), skipped)
+			}
+			got := schedules[0]
+			want := tt.reminders
+			if want == nil {
+				want = storage.DefaultReminderTimes
+			}
+			if got.Time != tt.want.Time || got.Duration != tt.want.Duration || got.ReminderType != tt.want.ReminderType ||
+				strings.Join(got.Days, ",") != strings.Join(tt.want.Days, ",") || !equalInts(got.ReminderTimes, want) {
+				t.Errorf("got %+v, want %+v with reminders %v", got, tt.want, want)
+			}
+		})
+	}
+}
+
+func TestDecodeNotCalendar(t *testing.T) {
+	_, _, err := Decode(strings.NewReader("hello\r\n"), time.UTC, time.Now())
+	if !errors.Is(err, ErrNotCalendar) {
+		t.Errorf("err = %v, want ErrNotCalendar", err)
+	}
+}
@@ -0,0 +1,306 @@
+// Package ical converts schedules to and from RFC 5545 iCalendar data.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+const (
+	dateTimeFormat = "20060102T150405"
+	prodID         = "-//TurSchedule//TurSchedule Bot//ID"
+
+	// exportHorizon bounds how far ahead skipped, paused and holiday
+	// dates are written as EXDATE and time zone transitions as
+	// observances. Feeds are refreshed long before it runs out.
+	exportHorizon = 366 * 24 * time.Hour
+)
+
+// byDay maps stored day names to RRULE BYDAY codes.
+var byDay = map[string]string{
+	"Sunday": "SU", "Monday": "MO", "Tuesday": "TU", "Wednesday": "WE",
+	"Thursday": "TH", "Friday": "FR", "Saturday": "SA",
+}
+
+// Encode writes schedules as a VCALENDAR with one VEVENT per schedule.
+// Recurring schedules get a weekly RRULE, reminder offsets become VALARMs
+// and times are expressed in loc. Occurrences that will not fire because
+// they are skipped, paused or fall on a holiday of holidays become EXDATEs,
+// and a schedule paused without a resume date ends at now. The local zone
+// is written as floating time because it has no IANA name to reference.
+func Encode(w io.Writer, schedules []*storage.Schedule, holidays *storage.HolidayCalendars, loc *time.Location, now time.Time) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + prodID)
+	e.line("CALSCALE:GREGORIAN")
+	e.line("METHOD:PUBLISH")
+	e.line("X-WR-CALNAME:TurSchedule")
+	if hasZoneName(loc) {
+		e.line("X-WR-TIMEZONE:" + loc.String())
+		e.timezone(loc, now)
+	}
+
+	for _, s := range schedules {
+		e.event(s, holidays, loc, now)
+	}
+
+	e.line("END:VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) event(s *storage.Schedule, holidays *storage.HolidayCalendars, loc *time.Location, now time.Time) {
+	now = now.In(loc)
+	today := midnight(now)
+	fires := func(at time.Time) bool {
+		_, holiday := holidays.Holiday(s, at)
+		return s.FiresOn(at) && !holiday
+	}
+
+	var (
+		start   time.Time
+		exdates []time.Time
+		until   time.Time
+	)
+	if s.ReminderType == storage.ReminderOnce {
+		// A once schedule fires at its next date that is not skipped; one
+		// paused without a resume date is shown at the date it waits for.
+		if s.ReminderSent[s.ID+"_main"] {
+			return
+		}
+		candidates := occurrences(s, now, now.Add(exportHorizon))
+		if len(candidates) == 0 {
+			return
+		}
+		start = candidates[0]
+		for _, at := range candidates {
+			if fires(at) {
+				start = at
+				break
+			}
+		}
+	} else {
+		// Anchor the series at the first weekly time on or after the
+		// creation date so DTSTART is itself an instance of the RRULE. Past
+		// occurrences are left alone: only dates from today on are checked.
+		anchor := s.CreatedAt
+		if anchor.IsZero() {
+			anchor = now
+		}
+		first := occurrences(s, midnight(anchor.In(loc)).Add(-time.Nanosecond), now.Add(exportHorizon))
+		if len(first) == 0 {
+			return
+		}
+		start = first[0]
+		if s.Paused && s.PausedUntil == "" {
+			until = now
+			if start.After(now) {
+				exdates = append(exdates, start)
+			}
+		} else {
+			for _, at := range first {
+				if !at.Before(today) && !fires(at) {
+					exdates = append(exdates, at)
+				}
+			}
+		}
+	}
+
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + s.ID + "@turschedule")
+	e.line("DTSTAMP:" + now.UTC().Format(dateTimeFormat) + "Z")
+	e.line(dateTimeProp("DTSTART", start, loc))
+	if s.Duration > 0 {
+		e.line(fmt.Sprintf("DURATION:PT%dM", s.Duration))
+	}
+	e.line("SUMMARY:" + escapeText(s.Title))
+	if s.Note != "" {
+		e.line("DESCRIPTION:" + escapeText(s.Note))
+	}
+	if len(s.Tags) > 0 {
+		// Tags never contain commas, so they need no escaping
+		e.line("CATEGORIES:" + strings.Join(s.Tags, ","))
+	}
+	if s.ReminderType != storage.ReminderOnce {
+		var days []string
+		for _, day := range s.Days {
+			if code, ok := byDay[day]; ok {
+				days = append(days, code)
+			}
+		}
+		rule := "RRULE:FREQ=WEEKLY;BYDAY=" + strings.Join(days, ",")
+		if !until.IsZero() {
+			rule += ";UNTIL=" + untilValue(until, loc)
+		}
+		e.line(rule)
+		for _, at := range exdates {
+			e.line(dateTimeProp("EXDATE", at, loc))
+		}
+	}
+	if !s.CreatedAt.IsZero() {
+		e.line("CREATED:" + s.CreatedAt.UTC().Format(dateTimeFormat) + "Z")
+	}
+	if !s.UpdatedAt.IsZero() {
+		e.line("LAST-MODIFIED:" + s.UpdatedAt.UTC().Format(dateTimeFormat) + "Z")
+	}
+
+	for _, minutes := range s.ReminderTimes {
+		e.line("BEGIN:VALARM")
+		e.line("ACTION:DISPLAY")
+		e.line("DESCRIPTION:" + escapeText(s.Title))
+		e.line(fmt.Sprintf("TRIGGER:-PT%dM", minutes))
+		e.line("END:VALARM")
+	}
+
+	e.line("END:VEVENT")
+}
+
+// timezone writes a VTIMEZONE for loc. The offset in effect a year before
+// now applies from 1970, followed by one observance per transition up to
+// exportHorizon after now, so zones with daylight saving get the right
+// offset on both sides of each change. Zones without transitions, such as
+// the Indonesian ones, get a single STANDARD observance.
+func (e *encoder) timezone(loc *time.Location, now time.Time) {
+	from := now.In(loc).Add(-exportHorizon)
+	name, offset := from.Zone()
+
+	e.line("BEGIN:VTIMEZONE")
+	e.line("TZID:" + loc.String())
+	e.observance(from.IsDST(), "19700101T000000", offset, offset, name)
+	for t := from; ; {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || end.After(now.Add(exportHorizon)) {
+			break
+		}
+		nextName, nextOffset := end.Zone()
+		// DTSTART is the onset in the wall-clock time before the change
+		onset := end.In(time.FixedZone("", offset)).Format(dateTimeFormat)
+		e.observance(end.IsDST(), onset, offset, nextOffset, nextName)
+		t, offset = end, nextOffset
+	}
+	e.line("END:VTIMEZONE")
+}
+
+func (e *encoder) observance(dst bool, start string, from, to int, name string) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+	e.line("BEGIN:" + kind)
+	e.line("DTSTART:" + start)
+	e.line("TZOFFSETFROM:" + formatOffset(from))
+	e.line("TZOFFSETTO:" + formatOffset(to))
+	e.line("TZNAME:" + name)
+	e.line("END:" + kind)
+}
+
+// line writes a content line folded as required by RFC 5545: no line may
+// exceed 75 octets, and continuation lines spend one of them on the
+// leading space.
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		// Do not split a multi-byte UTF-8 sequence
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, e.err = e.w.WriteString(s[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		s = s[cut:]
+		limit = 74
+	}
+	_, e.err = e.w.WriteString(s + "\r\n")
+}
+
+// occurrences returns the times in (after, until] that fall on the days and
+// time of s, ignoring pauses, skipped dates and holidays.
+func occurrences(s *storage.Schedule, after, until time.Time) []time.Time {
+	hour, minute, err := storage.ParseClock(s.Time)
+	if err != nil {
+		return nil
+	}
+	days := make(map[time.Weekday]bool, len(s.Days))
+	for _, day := range s.Days {
+		if wd, ok := storage.ParseWeekday(day); ok {
+			days[wd] = true
+		}
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	var result []time.Time
+	y, m, d := after.Date()
+	for i := 0; ; i++ {
+		at := time.Date(y, m, d+i, hour, minute, 0, 0, after.Location())
+		if at.After(until) {
+			return result
+		}
+		if days[at.Weekday()] && at.After(after) {
+			result = append(result, at)
+		}
+	}
+}
+
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// untilValue formats the UNTIL of an RRULE, which must be UTC when DTSTART
+// carries a zone and floating when DTSTART does.
+func untilValue(t time.Time, loc *time.Location) string {
+	if loc == time.UTC || hasZoneName(loc) {
+		return t.UTC().Format(dateTimeFormat) + "Z"
+	}
+	return t.Format(dateTimeFormat)
+}
+
+func dateTimeProp(name string, t time.Time, loc *time.Location) string {
+	switch {
+	case loc == time.UTC:
+		return name + ":" + t.UTC().Format(dateTimeFormat) + "Z"
+	case hasZoneName(loc):
+		return name + ";TZID=" + loc.String() + ":" + t.Format(dateTimeFormat)
+	default:
+		return name + ":" + t.Format(dateTimeFormat)
+	}
+}
+
+func hasZoneName(loc *time.Location) bool {
+	return loc != time.UTC && loc.String() != "Local" && loc.String() != "UTC"
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
@@ -0,0 +1,223 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("zona %s tidak tersedia: %v", name, err)
+	}
+	return loc
+}
+
+func encode(t *testing.T, schedules []*storage.Schedule, holidays *storage.HolidayCalendars, loc *time.Location, now time.Time) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(&buf, schedules, holidays, loc, now); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	// Monday 19 October 2026, 10:00 WIB
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name     string
+		schedule storage.Schedule
+	}{
+		{
+			name: "recurring",
+			schedule: storage.Schedule{
+				Title: "Rapat Tim", Time: "09:00", Duration: 90, Days: []string{"Monday", "Wednesday"},
+				Note: "Ruang 3; bawa laptop, charger", Tags: []string{"kantor"},
+				ReminderType: storage.ReminderRecurring, ReminderTimes: []int{60, 15},
+			},
+		},
+		{
+			name: "once",
+			schedule: storage.Schedule{
+				Title: "Servis motor", Time: "16:30", Days: []string{"Thursday"},
+				ReminderType: storage.ReminderOnce, ReminderTimes: []int{30},
+			},
+		},
+		{
+			name: "long title is folded",
+			schedule: storage.Schedule{
+				Title:        strings.Repeat("Kuliah Pemrograman Lanjut ", 6) + "ÄÖÜ é",
+				Time:         "07:30",
+				Days:         []string{"Tuesday"},
+				ReminderType: storage.ReminderRecurring, ReminderTimes: []int{10},
+			},
+		},
+		{
+			name: "paused until a date",
+			schedule: storage.Schedule{
+				Title: "Gym", Time: "18:00", Days: []string{"Friday"},
+				ReminderType: storage.ReminderRecurring, ReminderTimes: []int{30},
+				Paused: true, PausedUntil: "2026-11-01",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.schedule
+			s.ID = "42_1"
+			s.CreatedAt = now.AddDate(0, 0, -14)
+			s.ReminderSent = map[string]bool{}
+
+			data := encode(t, []*storage.Schedule{&s}, nil, jakarta, now)
+			for _, l := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+				if len(l) > 75 {
+					t.Errorf("line of %d octets: %q", len(l), l)
+				}
+			}
+
+			decoded, warnings, err := Decode(strings.NewReader(data), jakarta, now)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if len(decoded) != 1 {
+				t.Fatalf("decoded %d schedules, warnings %v", len(decoded), warnings)
+			}
+			got := decoded[0]
+			if got.Title != s.Title || got.Time != s.Time || got.Duration != s.Duration || got.Note != s.Note ||
+				strings.Join(got.Days, ",") != strings.Join(s.Days, ",") ||
+				strings.Join(got.Tags, ",") != strings.Join(s.Tags, ",") ||
+				got.ReminderType != s.ReminderType || !equalInts(got.ReminderTimes, s.ReminderTimes) {
+				t.Errorf("round trip\n got %+v\nwant %+v", got, s)
+			}
+		})
+	}
+}
+
+func TestEncodeExcludedDates(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, jakarta)
+	holidays := storage.NewHolidayCalendars()
+	if err := holidays.Add("id", []storage.Holiday{{Date: "2026-10-28", Name: "Sumpah Pemuda"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		change  func(s *storage.Schedule)
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "running",
+			change:  func(s *storage.Schedule) {},
+			want:    []string{"DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n"},
+			notWant: []string{"EXDATE", "UNTIL"},
+		},
+		{
+			name:   "skipped date",
+			change: func(s *storage.Schedule) { s.SkipDates = []string{"2026-10-21"} },
+			want:   []string{"EXDATE;TZID=Asia/Jakarta:20261021T090000"},
+		},
+		{
+			name:   "holiday",
+			change: func(s *storage.Schedule) { s.Holidays = []string{"id"} },
+			want:   []string{"EXDATE;TZID=Asia/Jakarta:20261028T090000"},
+		},
+		{
+			name:   "paused until a date",
+			change: func(s *storage.Schedule) { s.Pause(time.Date(2026, 10, 26, 0, 0, 0, 0, jakarta)) },
+			want:   []string{"EXDATE;TZID=Asia/Jakarta:20261019T090000", "EXDATE;TZID=Asia/Jakarta:20261021T090000"},
+			// Past occurrences and the ones from the resume date on stay
+			notWant: []string{"20261014T090000", "20261026T090000", "UNTIL"},
+		},
+		{
+			name:    "paused indefinitely",
+			change:  func(s *storage.Schedule) { s.Pause(time.Time{}) },
+			want:    []string{"DTSTART;TZID=Asia/Jakarta:20261005T090000", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20261019T030000Z"},
+			notWant: []string{"EXDATE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &storage.Schedule{
+				ID: "42_1", Title: "Rapat", Time: "09:00", Days: []string{"Monday", "Wednesday"},
+				ReminderType: storage.ReminderRecurring, ReminderSent: map[string]bool{},
+				CreatedAt: time.Date(2026, 10, 5, 8, 0, 0, 0, jakarta),
+			}
+			tt.change(s)
+			data := encode(t, []*storage.Schedule{s}, holidays, jakarta, now)
+			for _, want := range tt.want {
+				if !strings.Contains(data, want) {
+					t.Errorf("missing %q in\n%s", want, data)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(data, notWant) {
+					t.Errorf("unexpected %q in\n%s", notWant, data)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeTimezone(t *testing.T) {
+	tests := []struct {
+		zone    string
+		want    []string
+		notWant []string
+	}{
+		{
+			zone:    "Asia/Makassar",
+			want:    []string{"BEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:+0800\r\nTZOFFSETTO:+0800\r\nTZNAME:WITA"},
+			notWant: []string{"DAYLIGHT"},
+		},
+		{
+			zone: "Europe/Berlin",
+			want: []string{
+				"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST",
+				"BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET",
+				"BEGIN:DAYLIGHT\r\nDTSTART:20270328T020000\r\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			loc := mustLoad(t, tt.zone)
+			now := time.Date(2026, 10, 19, 10, 0, 0, 0, loc)
+			data := encode(t, nil, nil, loc, now)
+			for _, want := range tt.want {
+				if !strings.Contains(data, want) {
+					t.Errorf("missing %q in\n%s", want, data)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(data, notWant) {
+					t.Errorf("unexpected %q in\n%s", notWant, data)
+				}
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
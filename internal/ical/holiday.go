@@ -0,0 +1,140 @@
+package ical
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+// maxHolidayDays caps how many days a single multi-day event may cover.
+const maxHolidayDays = 31
+
+// DecodeHolidays reads the VEVENTs of a holiday calendar, such as an
+// exported national holiday calendar, and returns one holiday per covered
+// date. Multi-day events cover every date up to DTEND, which is exclusive.
+// Yearly RRULEs give yearly holidays.
+func DecodeHolidays(r io.Reader, loc *time.Location) ([]storage.Holiday, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		holidays []storage.Holiday
+		event    *vevent
+		inAlarm  bool
+	)
+	for _, l := range lines {
+		name, params, value := parseLine(l)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &vevent{params: make(map[string]map[string]string), props: make(map[string]string)}
+		case name == "END" && value == "VEVENT" && event != nil:
+			holidays = append(holidays, event.holidays(loc)...)
+			event = nil
+		case event == nil:
+			continue
+		case name == "BEGIN" && value == "VALARM":
+			inAlarm = true
+		case name == "END" && value == "VALARM":
+			inAlarm = false
+		case !inAlarm:
+			if _, seen := event.props[name]; !seen {
+				event.props[name] = value
+				event.params[name] = params
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// holidays expands the event into the dates it covers. Events without a
+// usable DTSTART are ignored.
+func (ev *vevent) holidays(loc *time.Location) []storage.Holiday {
+	title := strings.TrimSpace(unescapeText(ev.props["SUMMARY"]))
+	start, err := parseDateTime(ev.props["DTSTART"], ev.params["DTSTART"]["TZID"], loc)
+	if err != nil {
+		return nil
+	}
+	start = start.In(loc)
+	days := 1
+	if raw, ok := ev.props["DTEND"]; ok {
+		if end, err := parseDateTime(raw, ev.params["DTEND"]["TZID"], loc); err == nil {
+			days = dayIndex(end.In(loc)) - dayIndex(start)
+			if len(raw) > 8 && end.In(loc).Format("15:04") != "00:00" {
+				// A timed event ending later in the day still covers it
+				days++
+			}
+		}
+	}
+	if days < 1 {
+		days = 1
+	}
+	if days > maxHolidayDays {
+		days = maxHolidayDays
+	}
+
+	yearly := strings.Contains(strings.ToUpper(ev.props["RRULE"]), "FREQ=YEARLY")
+
+	var result []storage.Holiday
+	for d := 0; d < days; d++ {
+		date := time.Date(start.Year(), start.Month(), start.Day()+d, 0, 0, 0, 0, loc)
+		result = append(result, storage.Holiday{Date: date.Format(storage.DateLayout), Name: title, Yearly: yearly})
+	}
+	return result
+}
+
+// LoadHolidayCalendars loads every .ics and .json file in dir as a holiday
+// calendar named after the file, so "id.ics" becomes the calendar "id". A
+// JSON calendar is a list of {"date": "2026-12-25", "name": "Natal"}
+// objects. A missing directory yields no calendars.
+func LoadHolidayCalendars(dir string, loc *time.Location) (*storage.HolidayCalendars, error) {
+	calendars := storage.NewHolidayCalendars()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return calendars, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori kalender libur: %w", err)
+	}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".ics" && ext != ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		holidays, err := readHolidays(path, ext, loc)
+		if err != nil {
+			return nil, fmt.Errorf("kalender libur %s: %w", entry.Name(), err)
+		}
+		name := strings.ToLower(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+		if err := calendars.Add(name, holidays); err != nil {
+			return nil, fmt.Errorf("kalender libur %s: %w", entry.Name(), err)
+		}
+	}
+	return calendars, nil
+}
+
+func readHolidays(path, ext string, loc *time.Location) ([]storage.Holiday, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if ext == ".ics" {
+		return DecodeHolidays(f, loc)
+	}
+	var holidays []storage.Holiday
+	if err := json.NewDecoder(f).Decode(&holidays); err != nil {
+		return nil, fmt.Errorf("format JSON tidak valid: %w", err)
+	}
+	return holidays, nil
+}
@@ -0,0 +1,35 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+func TestDecodeHolidays(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Hari Kemerdekaan\r\nDTSTART;VALUE=DATE:20200817\r\nRRULE:FREQ=YEARLY\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Idul Fitri\r\nDTSTART;VALUE=DATE:20270310\r\nDTEND;VALUE=DATE:20270312\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	got, err := DecodeHolidays(strings.NewReader(data), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []storage.Holiday{
+		{Date: "2020-08-17", Name: "Hari Kemerdekaan", Yearly: true},
+		{Date: "2027-03-10", Name: "Idul Fitri"},
+		{Date: "2027-03-11", Name: "Idul Fitri"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package nlp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	isoDatePattern     = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	numericDatePattern = regexp.MustCompile(`^(\d{1,2})[/.-](\d{1,2})(?:[/.-](\d{2}|\d{4}))?$`)
+)
+
+var monthNames = map[string]time.Month{
+	"januari": time.January, "january": time.January, "jan": time.January,
+	"februari": time.February, "february": time.February, "feb": time.February,
+	"maret": time.March, "march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"mei": time.May, "may": time.May,
+	"juni": time.June, "june": time.June, "jun": time.June,
+	"juli": time.July, "july": time.July, "jul": time.July,
+	"agustus": time.August, "august": time.August, "agu": time.August, "agt": time.August, "aug": time.August,
+	"september": time.September, "sept": time.September, "sep": time.September,
+	"oktober": time.October, "october": time.October, "okt": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"desember": time.December, "december": time.December, "des": time.December, "dec": time.December,
+}
+
+// ParseDate parses a calendar date typed on its own, such as "2026-11-02",
+// "2/11", "02/11/2026", "2 November", "2 Nov 2026" or "besok", relative to
+// now. Day and month come in Indonesian order; a date without a year is the
+// next one on or after today. The result is midnight in now's location.
+func ParseDate(text string, now time.Time) (time.Time, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, ph := range phrases {
+		if ph.offset >= 0 && strings.Join(ph.words, " ") == text {
+			return today.AddDate(0, 0, ph.offset), true
+		}
+	}
+
+	if m := isoDatePattern.FindStringSubmatch(text); m != nil {
+		return buildDate(m[1], m[2], m[3], today)
+	}
+	if m := numericDatePattern.FindStringSubmatch(text); m != nil {
+		return buildDate(m[3], m[2], m[1], today)
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) != 2 && len(fields) != 3 {
+		return time.Time{}, false
+	}
+	month, ok := monthNames[strings.TrimSuffix(fields[1], ".")]
+	if !ok {
+		return time.Time{}, false
+	}
+	year := ""
+	if len(fields) == 3 {
+		year = fields[2]
+	}
+	return buildDate(year, strconv.Itoa(int(month)), fields[0], today)
+}
+
+// buildDate validates the parts of a date. An empty year picks the next
+// matching date on or after today; a two-digit year is in this century.
+func buildDate(year, month, day string, today time.Time) (time.Time, bool) {
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return time.Time{}, false
+	}
+	d, err := strconv.Atoi(day)
+	if err != nil || d < 1 || d > 31 {
+		return time.Time{}, false
+	}
+
+	y := today.Year()
+	if year != "" {
+		if y, err = strconv.Atoi(year); err != nil {
+			return time.Time{}, false
+		}
+		if y < 100 {
+			y += 2000
+		}
+	}
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, today.Location())
+	if date.Day() != d {
+		// 31 November and the like roll over into the next month
+		return time.Time{}, false
+	}
+	if year == "" && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, true
+}
@@ -0,0 +1,61 @@
+package nlp
+
+import (
+	"testing"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+func TestParseDate(t *testing.T) {
+	// Wednesday 21 October 2026, 10:00
+	now := time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"2026-11-02", "2026-11-02"},
+		{"2/11", "2026-11-02"},
+		{"02/11/2026", "2026-11-02"},
+		{"2-11-26", "2026-11-02"},
+		{"2.11.2027", "2027-11-02"},
+		{"2 November", "2026-11-02"},
+		{"2 nov 2026", "2026-11-02"},
+		{"2 Agt.", "2027-08-02"},
+		{"21 Oktober", "2026-10-21"},
+		// A date without a year that has passed is the one next year
+		{"20/10", "2027-10-20"},
+		{"hari ini", "2026-10-21"},
+		{"Besok", "2026-10-22"},
+		{"lusa", "2026-10-23"},
+		{"day after tomorrow", "2026-10-23"},
+		{"29/2/2028", "2028-02-29"},
+		{"29/2", ""},
+		{"31 November", ""},
+		{"2026-13-01", ""},
+		{"0/11", ""},
+		{"minggu depan", ""},
+		{"Senin", ""},
+		{"2 Brumaire", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseDate(tt.text, now)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("ParseDate(%q) = %s, want no date", tt.text, got.Format(storage.DateLayout))
+				}
+				return
+			}
+			if !ok || got.Format(storage.DateLayout) != tt.want {
+				t.Errorf("ParseDate(%q) = %s, %v; want %s", tt.text, got.Format(storage.DateLayout), ok, tt.want)
+			}
+			if got.Hour() != 0 || got.Minute() != 0 || got.Location() != now.Location() {
+				t.Errorf("ParseDate(%q) = %s, want midnight in %s", tt.text, got, now.Location())
+			}
+		})
+	}
+}
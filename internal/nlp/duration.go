@@ -0,0 +1,59 @@
+package nlp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// durationUnitPattern matches spelled-out durations such as "1 jam 30
+// menit", "1,5 jam", "90 min" or "2h".
+var durationUnitPattern = regexp.MustCompile(`^(?:(\d+(?:[.,]\d+)?)\s*(?:jam|j|h|hr|hrs|hour|hours))?\s*(?:(\d+)\s*(?:menit|mnt|m|min|mins|minute|minutes))?$`)
+
+// endMarkers introduce an end time, as in "sampai jam 11" or "until 5 pm".
+var endMarkers = []string{"sampai", "hingga", "until", "s/d", "-"}
+
+// ParseDuration parses how long an event starting at start ("HH:MM") lasts,
+// typed either as a duration ("90", "90 menit", "1,5 jam", "1 jam 30
+// menit", "2h") or as an end time ("10:30", "sampai jam 11"). It returns
+// the length in minutes; an end time must come after start on the same day.
+func ParseDuration(text, start string) (int, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if n, err := strconv.Atoi(text); err == nil {
+		return n, n > 0
+	}
+
+	if m := durationUnitPattern.FindStringSubmatch(text); m != nil && (m[1] != "" || m[2] != "") {
+		minutes := 0
+		if m[1] != "" {
+			hours, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+			if err != nil {
+				return 0, false
+			}
+			minutes += int(hours * 60)
+		}
+		if m[2] != "" {
+			n, _ := strconv.Atoi(m[2])
+			minutes += n
+		}
+		return minutes, minutes > 0
+	}
+
+	for _, marker := range endMarkers {
+		text = strings.TrimSpace(strings.TrimPrefix(text, marker))
+	}
+	end, ok := ParseTime(text)
+	if !ok {
+		return 0, false
+	}
+	minutes := clockMinutes(end) - clockMinutes(start)
+	return minutes, minutes > 0
+}
+
+// clockMinutes converts "HH:MM" to minutes since midnight.
+func clockMinutes(clock string) int {
+	h, m, _ := strings.Cut(clock, ":")
+	hour, _ := strconv.Atoi(h)
+	minute, _ := strconv.Atoi(m)
+	return hour*60 + minute
+}
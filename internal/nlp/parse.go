@@ -0,0 +1,493 @@
+// Package nlp turns free-text schedule descriptions in Indonesian or English
+// into schedule drafts, e.g. "rapat tim setiap Senin dan Rabu jam 09.30" or
+// "remind me to call mom tomorrow at 7 pm".
+package nlp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"turschedule/internal/storage"
+)
+
+// Result is the interpretation of a free-text description. Fields the text
+// did not mention are left empty.
+type Result struct {
+	Title        string
+	Time         string   // "HH:MM"
+	Days         []string // storage.Weekdays names
+	ReminderType string   // storage.ReminderOnce or storage.ReminderRecurring
+	Note         string
+
+	// Date is set when the text named a relative day such as "besok" or a
+	// single day of next week such as "Senin minggu depan".
+	Date time.Time
+
+	// Times lists every time the text named when it named more than one,
+	// as in "jam 8 dan jam 20". A schedule has a single time, so Time is
+	// left empty then.
+	Times []string
+}
+
+// Complete reports whether the result has every field a schedule needs.
+func (r *Result) Complete() bool {
+	return r.Title != "" && r.Time != "" && len(r.Days) > 0
+}
+
+var (
+	notePattern = regexp.MustCompile(`(?i)\s*\b(?:catatan|note|ket)\s*:\s*`)
+	timePattern = regexp.MustCompile(`^(\d{1,2})(?:[.:](\d{2}))?(am|pm|a\.m\.|p\.m\.)?$`)
+)
+
+var dayNames = map[string]string{
+	"senin": "Monday", "selasa": "Tuesday", "rabu": "Wednesday", "kamis": "Thursday",
+	"jumat": "Friday", "jum'at": "Friday", "sabtu": "Saturday", "minggu": "Sunday", "ahad": "Sunday",
+	"monday": "Monday", "tuesday": "Tuesday", "wednesday": "Wednesday", "thursday": "Thursday",
+	"friday": "Friday", "saturday": "Saturday", "sunday": "Sunday",
+	"mon": "Monday", "tue": "Tuesday", "tues": "Tuesday", "wed": "Wednesday", "thu": "Thursday",
+	"thur": "Thursday", "thurs": "Thursday", "fri": "Friday", "sat": "Saturday", "sun": "Sunday",
+}
+
+var (
+	weekdays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	weekend  = []string{"Saturday", "Sunday"}
+)
+
+// dayPhrase is a multi-word expression naming a set of days.
+type dayPhrase struct {
+	words     []string
+	days      []string
+	recurring bool
+	offset    int  // days from today for relative phrases, -1 otherwise
+	nextWeek  bool // the day named with it falls in the coming week
+}
+
+// phrases are matched longest first, so more specific entries come first.
+// They are tried before single day names, so "minggu depan" (next week) is
+// not read as the day Minggu.
+var phrases = []dayPhrase{
+	{[]string{"setiap", "hari", "kerja"}, weekdays, true, -1, false},
+	{[]string{"tiap", "hari", "kerja"}, weekdays, true, -1, false},
+	{[]string{"setiap", "akhir", "pekan"}, weekend, true, -1, false},
+	{[]string{"tiap", "akhir", "pekan"}, weekend, true, -1, false},
+	{[]string{"day", "after", "tomorrow"}, nil, false, 2, false},
+	{[]string{"hari", "kerja"}, weekdays, true, -1, false},
+	{[]string{"akhir", "pekan"}, weekend, true, -1, false},
+	{[]string{"setiap", "hari"}, storage.Weekdays, true, -1, false},
+	{[]string{"tiap", "hari"}, storage.Weekdays, true, -1, false},
+	{[]string{"every", "day"}, storage.Weekdays, true, -1, false},
+	{[]string{"every", "weekday"}, weekdays, true, -1, false},
+	{[]string{"every", "weekend"}, weekend, true, -1, false},
+	{[]string{"hari", "ini"}, nil, false, 0, false},
+	{[]string{"minggu", "depan"}, nil, false, -1, true},
+	{[]string{"pekan", "depan"}, nil, false, -1, true},
+	{[]string{"next", "week"}, nil, false, -1, true},
+	{[]string{"daily"}, storage.Weekdays, true, -1, false},
+	{[]string{"everyday"}, storage.Weekdays, true, -1, false},
+	{[]string{"weekdays"}, weekdays, true, -1, false},
+	{[]string{"weekend"}, weekend, true, -1, false},
+	{[]string{"weekends"}, weekend, true, -1, false},
+	{[]string{"today"}, nil, false, 0, false},
+	{[]string{"besok"}, nil, false, 1, false},
+	{[]string{"tomorrow"}, nil, false, 1, false},
+	{[]string{"lusa"}, nil, false, 2, false},
+}
+
+// Words that only mean "repeat" and never start a title.
+var recurringMarkers = map[string]bool{
+	"setiap": true, "tiap": true, "every": true, "each": true, "weekly": true, "mingguan": true,
+}
+
+// Connector words dropped from the title when they lead into a recognized
+// day or time, as in "rapat pada hari Senin jam 9".
+var connectors = map[string]bool{
+	"pada": true, "di": true, "hari": true, "jam": true, "pukul": true, "on": true, "at": true,
+	"@": true, "dan": true, "and": true, "&": true, ",": true, "setiap": true, "tiap": true,
+	"every": true, "each": true, "mulai": true,
+}
+
+// Words that introduce a time, as in "jam 9" or "at 9".
+var timePrefixes = map[string]bool{"jam": true, "pukul": true, "at": true, "@": true}
+
+// Leading phrases that ask the bot for a reminder rather than name it.
+var prefixes = [][]string{
+	{"ingatkan", "saya", "untuk"}, {"ingatkan", "aku", "untuk"}, {"ingatkan", "saya"},
+	{"ingatkan", "aku"}, {"ingatkan"}, {"remind", "me", "to"}, {"remind", "me"}, {"tolong"},
+}
+
+// Parse interprets text relative to now. Times of day follow the Indonesian
+// "pagi/siang/sore/malam" and English "am/pm" conventions. Relative days
+// make a "once" schedule and repeat markers or several days a recurring
+// one; a single day name alone, as in "kuliah hari Minggu", leaves
+// ReminderType empty for the chat's default.
+func Parse(text string, now time.Time) *Result {
+	r := &Result{}
+
+	if loc := notePattern.FindStringIndex(text); loc != nil {
+		r.Note = strings.TrimSpace(text[loc[1]:])
+		text = text[:loc[0]]
+	}
+
+	p := newParser(text)
+	p.skipPrefix()
+
+	recurring, relative, nextWeek := false, -1, false
+	days := make(map[string]bool)
+	var times []string
+	for i := 0; i < len(p.lower); i++ {
+		if p.used[i] {
+			continue
+		}
+
+		word := p.lower[i]
+		// "setiap hari Senin" names a day, it does not mean every day
+		if recurringMarkers[word] && p.peek(i+1) == "hari" && dayNames[p.peek(i+2)] != "" {
+			p.consume(i, 2)
+			recurring = true
+			i++
+			continue
+		}
+
+		if ph, n := p.matchPhrase(i); n > 0 {
+			p.consume(i, n)
+			for _, d := range ph.days {
+				days[d] = true
+			}
+			if ph.recurring {
+				recurring = true
+			}
+			if ph.offset >= 0 {
+				relative = ph.offset
+			}
+			if ph.nextWeek {
+				nextWeek = true
+			}
+			i += n - 1
+			continue
+		}
+
+		// "setiap minggu" means every week, "setiap hari Minggu" every Sunday
+		if recurringMarkers[word] && i+1 < len(p.lower) && (p.lower[i+1] == "minggu" || p.lower[i+1] == "week") {
+			p.consume(i, 2)
+			recurring = true
+			i++
+			continue
+		}
+		if recurringMarkers[word] {
+			p.consume(i, 1)
+			recurring = true
+			continue
+		}
+		if day, ok := dayNames[word]; ok {
+			p.consume(i, 1)
+			days[day] = true
+			continue
+		}
+		// English plurals such as "Mondays" describe a weekly habit
+		if day, ok := dayNames[strings.TrimSuffix(word, "s")]; ok && len(word) > 4 {
+			p.consume(i, 1)
+			days[day] = true
+			recurring = true
+			continue
+		}
+		if clock, n, ok := p.matchTime(i, false); ok {
+			p.consume(i, n)
+			if !containsString(times, clock) {
+				times = append(times, clock)
+			}
+			i += n - 1
+		}
+	}
+
+	switch len(times) {
+	case 0:
+	case 1:
+		r.Time = times[0]
+	default:
+		r.Times = times
+	}
+
+	y, m, d := now.Date()
+	if relative >= 0 {
+		r.Date = time.Date(y, m, d+relative, 0, 0, 0, 0, now.Location())
+		days = map[string]bool{r.Date.Weekday().String(): true}
+	}
+	for _, d := range storage.Weekdays {
+		if days[d] {
+			r.Days = append(r.Days, d)
+		}
+	}
+	if nextWeek && relative < 0 && len(r.Days) == 1 {
+		// Weeks start on Monday, so on a Sunday next week starts tomorrow
+		wd, _ := storage.ParseWeekday(r.Days[0])
+		monday := d + 7 - (int(now.Weekday())+6)%7
+		r.Date = time.Date(y, m, monday+(int(wd)+6)%7, 0, 0, 0, 0, now.Location())
+	}
+
+	switch {
+	case relative >= 0 || nextWeek:
+		r.ReminderType = storage.ReminderOnce
+	case recurring || len(r.Days) > 1:
+		r.ReminderType = storage.ReminderRecurring
+	}
+
+	r.Title = p.title()
+	return r
+}
+
+// ParseTime parses a time typed on its own, such as "9.30", "09:30",
+// "9:30 pm", "21.15" or "jam 7 malam", and returns it as "HH:MM".
+func ParseTime(text string) (string, bool) {
+	p := newParser(text)
+	i := 0
+	for i < len(p.lower) && timePrefixes[p.lower[i]] {
+		i++
+	}
+	if i == len(p.lower) {
+		return "", false
+	}
+
+	clock, n, ok := p.matchTime(i, true)
+	if !ok || i+n != len(p.lower) {
+		return "", false
+	}
+	return clock, true
+}
+
+type parser struct {
+	orig  []string
+	lower []string
+	used  []bool
+}
+
+func newParser(text string) *parser {
+	p := &parser{}
+	for _, f := range strings.Fields(strings.ReplaceAll(text, ",", " , ")) {
+		word := strings.TrimRight(f, "!?;")
+		lw := strings.ToLower(word)
+		if strings.HasSuffix(lw, ".") && lw != "a.m." && lw != "p.m." {
+			word, lw = word[:len(word)-1], lw[:len(lw)-1]
+		}
+		if word == "" {
+			continue
+		}
+		p.orig = append(p.orig, word)
+		p.lower = append(p.lower, lw)
+	}
+	p.used = make([]bool, len(p.orig))
+	return p
+}
+
+func (p *parser) consume(i, n int) {
+	for j := i; j < i+n && j < len(p.used); j++ {
+		p.used[j] = true
+	}
+}
+
+func (p *parser) hasWords(i int, words []string) bool {
+	if i+len(words) > len(p.lower) {
+		return false
+	}
+	for j, w := range words {
+		if p.lower[i+j] != w {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) skipPrefix() {
+	for _, prefix := range prefixes {
+		if p.hasWords(0, prefix) {
+			p.consume(0, len(prefix))
+			return
+		}
+	}
+}
+
+func (p *parser) matchPhrase(i int) (dayPhrase, int) {
+	for _, ph := range phrases {
+		if p.hasWords(i, ph.words) {
+			return ph, len(ph.words)
+		}
+	}
+	return dayPhrase{}, 0
+}
+
+// matchTime recognizes a time expression starting at token i and returns it
+// as "HH:MM" together with the number of tokens it spans. With bare, a plain
+// hour such as "9" is accepted without a leading "jam" or "at".
+func (p *parser) matchTime(i int, bare bool) (string, int, bool) {
+	word := p.lower[i]
+	switch {
+	case word == "noon" || p.hasWords(i, []string{"tengah", "hari"}):
+		return "12:00", 1 + boolInt(word != "noon"), true
+	case word == "midnight" || p.hasWords(i, []string{"tengah", "malam"}):
+		return "00:00", 1 + boolInt(word != "midnight"), true
+	}
+
+	// A bare number only counts as a time after "jam", "pukul" or "at"
+	prefixed := bare || i > 0 && !p.used[i-1] && timePrefixes[p.lower[i-1]]
+
+	n := 1
+	half := false
+	if word == "setengah" && i+1 < len(p.lower) {
+		// "setengah 8" is half an hour before eight
+		half = true
+		word = p.lower[i+1]
+		n = 2
+	}
+
+	m := timePattern.FindStringSubmatch(word)
+	if m == nil {
+		return "", 0, false
+	}
+	hasMinutes, suffix := m[2] != "", strings.ReplaceAll(m[3], ".", "")
+	if !prefixed && !hasMinutes && suffix == "" && !half {
+		if next := p.peek(i + n); next != "am" && next != "pm" && next != "a.m." && next != "p.m." {
+			return "", 0, false
+		}
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	if half {
+		if hasMinutes {
+			return "", 0, false
+		}
+		hour, minute = hour-1, 30
+		if hour < 0 {
+			hour = 23
+		}
+	}
+
+	period := suffix
+	if period == "" {
+		period = strings.ReplaceAll(p.peek(i+n), ".", "")
+		switch period {
+		case "am", "pm", "pagi", "siang", "sore", "malam":
+			n++
+		default:
+			period = ""
+		}
+	}
+	if zone := p.peek(i + n); zone == "wib" || zone == "wita" || zone == "wit" {
+		n++
+	}
+
+	hour, ok := applyPeriod(hour, period)
+	if !ok || minute > 59 {
+		return "", 0, false
+	}
+	return formatClock(hour, minute), n, true
+}
+
+func (p *parser) peek(i int) string {
+	if i < len(p.lower) {
+		return p.lower[i]
+	}
+	return ""
+}
+
+// title joins the tokens that were not recognized as days, times or
+// connector words leading into them.
+func (p *parser) title() string {
+	// Drop connectors directly in front of a recognized token, right to
+	// left so chains like "pada hari" collapse together.
+	for i := len(p.lower) - 2; i >= 0; i-- {
+		if !p.used[i] && connectors[p.lower[i]] && p.used[i+1] {
+			p.used[i] = true
+		}
+	}
+
+	var words []string
+	for i, w := range p.orig {
+		if !p.used[i] {
+			words = append(words, w)
+		}
+	}
+	// Trailing connectors are leftovers such as "rapat tim di"
+	for len(words) > 0 && connectors[strings.ToLower(words[len(words)-1])] {
+		words = words[:len(words)-1]
+	}
+	for len(words) > 0 && connectors[strings.ToLower(words[0])] {
+		words = words[1:]
+	}
+
+	title := strings.ReplaceAll(strings.Join(words, " "), " ,", ",")
+	if title == "" {
+		return ""
+	}
+	first, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// applyPeriod converts a 12-hour clock with an am/pm or Indonesian
+// day-period word to 24-hour time.
+func applyPeriod(hour int, period string) (int, bool) {
+	switch period {
+	case "":
+		return hour, hour <= 23
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		return hour % 12, true
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		return hour%12 + 12, true
+	case "pagi":
+		if hour > 12 {
+			return 0, false
+		}
+		return hour % 12, true
+	case "siang":
+		// "jam 12 siang" is noon and "jam 1 siang" is 13:00
+		if hour >= 1 && hour <= 5 {
+			return hour + 12, true
+		}
+		return hour, hour <= 23
+	case "sore":
+		if hour >= 1 && hour <= 11 {
+			return hour + 12, true
+		}
+		return hour, hour <= 23
+	case "malam":
+		switch {
+		case hour == 12:
+			return 0, true
+		case hour >= 6 && hour <= 11:
+			return hour + 12, true
+		default:
+			return hour, hour <= 23
+		}
+	}
+	return 0, false
+}
+
+func formatClock(hour, minute int) string {
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
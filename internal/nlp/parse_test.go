@@ -0,0 +1,139 @@
+package nlp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"turschedule/internal/storage"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday 21 October 2026, 10:00
+	now := time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text  string
+		title string
+		time  string
+		days  string
+		typ   string
+		date  string
+		times string
+		note  string
+	}{
+		{
+			text: "rapat tim setiap Senin dan Rabu jam 09.30", title: "Rapat tim", time: "09:30",
+			days: "Monday,Wednesday", typ: storage.ReminderRecurring,
+		},
+		{
+			text: "remind me to call mom tomorrow at 7 pm", title: "Call mom", time: "19:00",
+			days: "Thursday", typ: storage.ReminderOnce, date: "2026-10-22",
+		},
+		{
+			text: "hari ini jam 7 olahraga", title: "Olahraga", time: "07:00",
+			days: "Wednesday", typ: storage.ReminderOnce, date: "2026-10-21",
+		},
+		{
+			text: "lusa jam setengah 8 malam nonton", title: "Nonton", time: "19:30",
+			days: "Friday", typ: storage.ReminderOnce, date: "2026-10-23",
+		},
+		{
+			text: "olahraga setiap hari kerja jam 6 pagi", title: "Olahraga", time: "06:00",
+			days: "Monday,Tuesday,Wednesday,Thursday,Friday", typ: storage.ReminderRecurring,
+		},
+		{
+			text: "ibadah setiap hari Minggu jam 8", title: "Ibadah", time: "08:00",
+			days: "Sunday", typ: storage.ReminderRecurring,
+		},
+		{
+			text: "arisan setiap minggu hari Sabtu jam 16.00", title: "Arisan", time: "16:00",
+			days: "Saturday", typ: storage.ReminderRecurring,
+		},
+		{
+			// A single day name does not say once or recurring
+			text: "kuliah hari Minggu jam 8", title: "Kuliah", time: "08:00", days: "Sunday",
+		},
+		{
+			text: "gym on Fridays at 6pm", title: "Gym", time: "18:00",
+			days: "Friday", typ: storage.ReminderRecurring,
+		},
+		{
+			// "minggu depan" is next week, not the day Minggu
+			text: "rapat minggu depan jam 9", title: "Rapat", time: "09:00", typ: storage.ReminderOnce,
+		},
+		{
+			text: "rapat Senin minggu depan jam 9", title: "Rapat", time: "09:00",
+			days: "Monday", typ: storage.ReminderOnce, date: "2026-10-26",
+		},
+		{
+			// Next week starts on Monday, so its Wednesday is a week away
+			text: "presentasi next week Wednesday at 10am", title: "Presentasi", time: "10:00",
+			days: "Wednesday", typ: storage.ReminderOnce, date: "2026-10-28",
+		},
+		{
+			// A schedule has a single time, so several are reported
+			text: "minum obat jam 8 dan jam 20 setiap hari", title: "Minum obat", times: "08:00,20:00",
+			days: strings.Join(storage.Weekdays, ","), typ: storage.ReminderRecurring,
+		},
+		{
+			text: "bayar listrik tanggal besok jam 9 catatan: pakai m-banking", title: "Bayar listrik tanggal",
+			time: "09:00", days: "Thursday", typ: storage.ReminderOnce, date: "2026-10-22", note: "pakai m-banking",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			r := Parse(tt.text, now)
+			date := ""
+			if !r.Date.IsZero() {
+				date = r.Date.Format(storage.DateLayout)
+			}
+			if r.Title != tt.title || r.Time != tt.time || strings.Join(r.Days, ",") != tt.days ||
+				r.ReminderType != tt.typ || date != tt.date || strings.Join(r.Times, ",") != tt.times || r.Note != tt.note {
+				t.Errorf("Parse(%q)\n got title=%q time=%q days=%v type=%q date=%q times=%v note=%q\nwant title=%q time=%q days=%s type=%q date=%q times=%s note=%q",
+					tt.text, r.Title, r.Time, r.Days, r.ReminderType, date, r.Times, r.Note,
+					tt.title, tt.time, tt.days, tt.typ, tt.date, tt.times, tt.note)
+			}
+		})
+	}
+}
+
+func TestNextWeekOnSunday(t *testing.T) {
+	// Sunday 25 October 2026: next week starts tomorrow
+	now := time.Date(2026, 10, 25, 10, 0, 0, 0, time.UTC)
+	r := Parse("rapat Senin minggu depan jam 9", now)
+	if got := r.Date.Format(storage.DateLayout); got != "2026-10-26" {
+		t.Errorf("date = %s, want 2026-10-26", got)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{"9.30", "09:30", true},
+		{"09:30", "09:30", true},
+		{"9:30 pm", "21:30", true},
+		{"21.15", "21:15", true},
+		{"jam 7 malam", "19:00", true},
+		{"setengah 8", "07:30", true},
+		{"jam 12 siang", "12:00", true},
+		{"tengah malam", "00:00", true},
+		{"13 pm", "", false},
+		{"25.00", "", false},
+		{"jam 8 dan jam 9", "", false},
+		{"besok", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseTime(tt.text)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseTime(%q) = %q, %v; want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
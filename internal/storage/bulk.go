@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// clone returns a deep copy of s.
+func (s *Schedule) clone() (*Schedule, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menyalin jadwal %s: %w", s.ID, err)
+	}
+	copied := new(Schedule)
+	if err := json.Unmarshal(data, copied); err != nil {
+		return nil, fmt.Errorf("gagal menyalin jadwal %s: %w", s.ID, err)
+	}
+	return copied, nil
+}
+
+// cloneAll returns deep copies of schedules.
+func cloneAll(schedules []*Schedule) ([]*Schedule, error) {
+	copies := make([]*Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		copied, err := schedule.clone()
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, copied)
+	}
+	return copies, nil
+}
+
+// BulkUpdate applies change to every schedule in ids and stores them with a
+// single write. Either all of them change or none do: nothing is stored
+// when an id is unknown, change fails, a result does not validate or the
+// file cannot be written. It returns the schedules as they were before, for
+// Restore.
+func (us *UserSchedules) BulkUpdate(ids []string, change func(*Schedule) error) ([]*Schedule, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	previous := make([]*Schedule, 0, len(ids))
+	after := make([]*Schedule, 0, len(ids))
+	for _, id := range ids {
+		schedule, exists := us.Schedules[id]
+		if !exists {
+			return nil, fmt.Errorf("schedule tidak ditemukan")
+		}
+		updated, err := schedule.clone()
+		if err != nil {
+			return nil, err
+		}
+		if err := change(updated); err != nil {
+			return nil, fmt.Errorf("%s: %w", schedule.Title, err)
+		}
+		if err := updated.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", schedule.Title, err)
+		}
+		updated.UpdatedAt = time.Now()
+		previous = append(previous, schedule)
+		after = append(after, updated)
+	}
+	before, err := cloneAll(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the pointers rather than the values they point to, which
+	// reminders and nags read without holding the lock
+	for i, id := range ids {
+		us.Schedules[id] = after[i]
+	}
+	if err := us.saveUnlocked(); err != nil {
+		for i, id := range ids {
+			us.Schedules[id] = previous[i]
+		}
+		return nil, err
+	}
+	return before, nil
+}
+
+// BulkDelete removes every schedule in ids with a single write, or none of
+// them if one is unknown or the file cannot be written. It returns the
+// deleted schedules, for Restore.
+func (us *UserSchedules) BulkDelete(ids []string) ([]*Schedule, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	deleted := make([]*Schedule, 0, len(ids))
+	for _, id := range ids {
+		schedule, exists := us.Schedules[id]
+		if !exists {
+			return nil, fmt.Errorf("schedule tidak ditemukan")
+		}
+		deleted = append(deleted, schedule)
+	}
+	before, err := cloneAll(deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		delete(us.Schedules, id)
+	}
+	if err := us.saveUnlocked(); err != nil {
+		for _, schedule := range deleted {
+			us.Schedules[schedule.ID] = schedule
+		}
+		return nil, err
+	}
+	return before, nil
+}
+
+// Restore puts schedules returned by BulkUpdate or BulkDelete back as they
+// were with a single write, adding the ones that were deleted since.
+func (us *UserSchedules) Restore(schedules []*Schedule) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	restored, err := cloneAll(schedules)
+	if err != nil {
+		return err
+	}
+
+	// A nil previous schedule marks one that was deleted since
+	previous := make(map[string]*Schedule, len(schedules))
+	for _, schedule := range restored {
+		previous[schedule.ID] = us.Schedules[schedule.ID]
+		us.Schedules[schedule.ID] = schedule
+	}
+	if err := us.saveUnlocked(); err != nil {
+		for id, schedule := range previous {
+			if schedule == nil {
+				delete(us.Schedules, id)
+			} else {
+				us.Schedules[id] = schedule
+			}
+		}
+		return err
+	}
+	return nil
+}
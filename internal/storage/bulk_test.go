@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// bulkStorage returns a store holding the schedules 42_1 at 09:00 and 42_2
+// at 13:00.
+func bulkStorage(t *testing.T) *UserSchedules {
+	t.Helper()
+	us, err := NewUserSchedules(filepath.Join(t.TempDir(), "schedules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []*Schedule{
+		{ID: "42_1", UserID: 42, Title: "Rapat", Time: "09:00", Days: []string{"Monday"}},
+		{ID: "42_2", UserID: 42, Title: "Kuliah", Time: "13:00", Days: []string{"Tuesday"}},
+	} {
+		s.ReminderType = ReminderRecurring
+		s.ReminderSent = map[string]bool{}
+		if err := us.AddSchedule(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return us
+}
+
+// failSaves points us at a directory so every write fails.
+func failSaves(t *testing.T, us *UserSchedules) {
+	us.filePath = t.TempDir()
+}
+
+func times(us *UserSchedules) string {
+	return us.Schedules["42_1"].Time + "," + us.Schedules["42_2"].Time
+}
+
+func TestBulkUpdate(t *testing.T) {
+	later := func(s *Schedule) error {
+		s.Time = "10:" + s.Time[3:]
+		return nil
+	}
+
+	tests := []struct {
+		name   string
+		ids    []string
+		change func(s *Schedule) error
+		fail   bool
+		want   string
+		err    bool
+	}{
+		{name: "all", ids: []string{"42_1", "42_2"}, change: later, want: "10:00,10:00"},
+		{name: "one", ids: []string{"42_2"}, change: later, want: "09:00,10:00"},
+		{name: "unknown id", ids: []string{"42_1", "42_9"}, change: later, want: "09:00,13:00", err: true},
+		{
+			name: "change fails",
+			ids:  []string{"42_1", "42_2"},
+			change: func(s *Schedule) error {
+				if s.ID == "42_2" {
+					return errors.New("gagal")
+				}
+				return later(s)
+			},
+			want: "09:00,13:00",
+			err:  true,
+		},
+		{
+			name:   "invalid result",
+			ids:    []string{"42_1", "42_2"},
+			change: func(s *Schedule) error { s.Days = nil; return nil },
+			want:   "09:00,13:00",
+			err:    true,
+		},
+		{name: "save fails", ids: []string{"42_1", "42_2"}, change: later, fail: true, want: "09:00,13:00", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us := bulkStorage(t)
+			held := us.Schedules["42_1"]
+			if tt.fail {
+				failSaves(t, us)
+			}
+
+			before, err := us.BulkUpdate(tt.ids, tt.change)
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+			if got := times(us); got != tt.want {
+				t.Errorf("times = %s, want %s", got, tt.want)
+			}
+			// The stored schedule is replaced, never changed in place
+			if held.Time != "09:00" {
+				t.Errorf("held schedule changed to %s", held.Time)
+			}
+			if err != nil {
+				if us.Schedules["42_1"] != held {
+					t.Errorf("rollback did not put the previous schedule back")
+				}
+				return
+			}
+			if len(before) != len(tt.ids) || before[0] == held {
+				t.Errorf("before = %v, want copies of %v", before, tt.ids)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(us *UserSchedules) ([]*Schedule, error)
+		fail   bool
+		want   string
+	}{
+		{
+			name: "update",
+			action: func(us *UserSchedules) ([]*Schedule, error) {
+				return us.BulkUpdate([]string{"42_1", "42_2"}, func(s *Schedule) error { s.Time = "20:00"; return nil })
+			},
+			want: "09:00,13:00",
+		},
+		{
+			name: "delete",
+			action: func(us *UserSchedules) ([]*Schedule, error) {
+				return us.BulkDelete([]string{"42_1", "42_2"})
+			},
+			want: "09:00,13:00",
+		},
+		{
+			name: "save fails after update",
+			action: func(us *UserSchedules) ([]*Schedule, error) {
+				return us.BulkUpdate([]string{"42_1", "42_2"}, func(s *Schedule) error { s.Time = "20:00"; return nil })
+			},
+			fail: true,
+			want: "20:00,20:00",
+		},
+		{
+			name: "save fails after delete",
+			action: func(us *UserSchedules) ([]*Schedule, error) {
+				return us.BulkDelete([]string{"42_2"})
+			},
+			fail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us := bulkStorage(t)
+			before, err := tt.action(us)
+			if err != nil {
+				t.Fatal(err)
+			}
+			current := us.Schedules["42_1"]
+			if tt.fail {
+				failSaves(t, us)
+			}
+
+			err = us.Restore(before)
+			if (err != nil) != tt.fail {
+				t.Fatalf("err = %v, want error %v", err, tt.fail)
+			}
+			if tt.fail {
+				if us.Schedules["42_1"] != current {
+					t.Errorf("rollback did not put the previous schedule back")
+				}
+				if _, exists := us.Schedules["42_2"]; exists && tt.want == "" {
+					t.Errorf("rollback kept the restored schedule 42_2")
+				}
+				if tt.want != "" && times(us) != tt.want {
+					t.Errorf("times = %s, want %s", times(us), tt.want)
+				}
+				return
+			}
+			if got := times(us); got != tt.want {
+				t.Errorf("times = %s, want %s", got, tt.want)
+			}
+
+			// The restored state is what gets written
+			reloaded, err := NewUserSchedules(us.filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := times(reloaded); got != tt.want {
+				t.Errorf("saved times = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MinutesPerDay bounds durations and working hours, which never cross
+// midnight.
+const MinutesPerDay = 24 * 60
+
+// Slot is a span of the day in minutes since midnight, End exclusive.
+type Slot struct {
+	Start int
+	End   int
+}
+
+// String formats the slot as "08:00-17:00".
+func (s Slot) String() string {
+	return FormatClock(s.Start) + "-" + FormatClock(s.End)
+}
+
+// FormatClock formats minutes since midnight as "HH:MM"; 1440 becomes
+// "24:00".
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ParseSlot parses a range such as "08:00-17:00" or "8.30 - 12".
+func ParseSlot(text string) (Slot, error) {
+	from, to, ok := strings.Cut(strings.ReplaceAll(text, "–", "-"), "-")
+	start, okStart := parseSlotClock(from)
+	end, okEnd := parseSlotClock(to)
+	if !ok || !okStart || !okEnd {
+		return Slot{}, fmt.Errorf("rentang waktu '%s' tidak valid, contoh 08:00-17:00", strings.TrimSpace(text))
+	}
+	if end <= start {
+		return Slot{}, fmt.Errorf("jam selesai harus setelah jam mulai")
+	}
+	return Slot{Start: start, End: end}, nil
+}
+
+// parseSlotClock parses "8", "8.30" or "08:30" into minutes since midnight,
+// allowing "24:00" as the end of the day.
+func parseSlotClock(text string) (int, bool) {
+	h, m, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(text), ".", ":"), ":")
+	if m == "" {
+		m = "00"
+	}
+	hour, err := strconv.Atoi(h)
+	if err != nil || len(m) != 2 {
+		return 0, false
+	}
+	minute, err := strconv.Atoi(m)
+	if err != nil || hour < 0 || minute < 0 || minute > 59 || hour*60+minute > MinutesPerDay {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
+
+// Span returns the part of the day s occupies. A schedule without a
+// duration occupies its starting minute.
+func (s *Schedule) Span() Slot {
+	hour, minute, _ := ParseClock(s.Time)
+	start := hour*60 + minute
+	duration := s.Duration
+	if duration < 1 {
+		duration = 1
+	}
+	return Slot{Start: start, End: start + duration}
+}
+
+// EndTime returns when s ends as "HH:MM", or "" when it has no duration.
+func (s *Schedule) EndTime() string {
+	if s.Duration <= 0 {
+		return ""
+	}
+	return FormatClock(s.Span().End)
+}
+
+// Overlaps reports whether s and other share a day and their spans overlap.
+func (s *Schedule) Overlaps(other *Schedule) bool {
+	a, b := s.Span(), other.Span()
+	if a.Start >= b.End || b.Start >= a.End {
+		return false
+	}
+	for _, day := range s.Days {
+		if containsString(other.Days, day) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflicts returns the other schedules of s's chat that overlap it, sorted
+// by time. Schedules paused without a resume date are not in the way.
+func (us *UserSchedules) Conflicts(s *Schedule) []*Schedule {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	var result []*Schedule
+	for _, other := range us.Schedules {
+		if other.ID == s.ID || other.UserID != s.UserID || (other.Paused && other.PausedUntil == "") {
+			continue
+		}
+		if s.Overlaps(other) {
+			result = append(result, other)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Time != result[j].Time {
+			return result[i].Time < result[j].Time
+		}
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+// FreeSlots returns the parts of within not covered by busy, in order.
+func FreeSlots(within Slot, busy []Slot) []Slot {
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start < busy[j].Start })
+
+	var free []Slot
+	cursor := within.Start
+	for _, b := range busy {
+		if b.End <= cursor || b.Start >= within.End {
+			continue
+		}
+		if b.Start > cursor {
+			free = append(free, Slot{Start: cursor, End: b.Start})
+		}
+		if b.End > cursor {
+			cursor = b.End
+		}
+	}
+	if cursor < within.End {
+		free = append(free, Slot{Start: cursor, End: within.End})
+	}
+	return free
+}
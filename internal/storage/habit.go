@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// habitRetention is how long fired and completed dates are kept for /stats.
+const habitRetention = 366 * 24 * time.Hour
+
+// HabitStats summarizes how often a recurring schedule was completed.
+// Fired counts the occurrences whose main notification went out, except a
+// pending one today, and Done how many of those were completed. Current and
+// Longest are runs of completed occurrences in a row.
+type HabitStats struct {
+	Fired   int
+	Done    int
+	Current int
+	Longest int
+}
+
+// Rate returns the completion rate as a percentage.
+func (h HabitStats) Rate() int {
+	if h.Fired == 0 {
+		return 0
+	}
+	return h.Done * 100 / h.Fired
+}
+
+// IsDone reports whether the occurrence of s on date was completed.
+func (s *Schedule) IsDone(date string) bool {
+	return containsString(s.DoneDates, date)
+}
+
+// HasFired reports whether the main notification of s went out on date.
+func (s *Schedule) HasFired(date string) bool {
+	return containsString(s.FiredDates, date)
+}
+
+// Habit computes the completion statistics of s. An occurrence on today
+// that is not completed yet neither counts nor breaks the current run.
+func (s *Schedule) Habit(today string) HabitStats {
+	var stats HabitStats
+	run := 0
+	for _, date := range s.FiredDates {
+		switch {
+		case s.IsDone(date):
+			stats.Fired++
+			stats.Done++
+			run++
+			if run > stats.Longest {
+				stats.Longest = run
+			}
+		case date == today:
+		default:
+			stats.Fired++
+			run = 0
+		}
+	}
+	stats.Current = run
+	return stats
+}
+
+// MarkFired records that the main notification of schedule id went out
+// for the occurrence on date.
+func (us *UserSchedules) MarkFired(id, date string) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+	if containsString(schedule.FiredDates, date) {
+		return nil
+	}
+	schedule.FiredDates = appendDate(schedule.FiredDates, date)
+	return us.saveUnlocked()
+}
+
+// Complete records the occurrence of schedule id on date as done. It
+// reports whether it was not recorded yet.
+func (us *UserSchedules) Complete(id, date string) (bool, error) {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return false, fmt.Errorf("tanggal '%s' tidak valid", date)
+	}
+
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return false, fmt.Errorf("schedule tidak ditemukan")
+	}
+	if containsString(schedule.DoneDates, date) {
+		return false, nil
+	}
+	schedule.DoneDates = appendDate(schedule.DoneDates, date)
+	return true, us.saveUnlocked()
+}
+
+// appendDate returns a new sorted slice of dates with date added and the
+// dates older than habitRetention dropped. The old slice is left untouched
+// as notifications read it without holding the lock.
+func appendDate(dates []string, date string) []string {
+	cutoff := time.Now().Add(-habitRetention).Format(DateLayout)
+	result := []string{date}
+	for _, d := range dates {
+		if d >= cutoff {
+			result = append(result, d)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
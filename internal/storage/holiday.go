@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Holiday is a single date of a holiday calendar. A yearly holiday falls
+// on the same month and day every year from Date on.
+type Holiday struct {
+	Date   string `json:"date"` // DateLayout
+	Name   string `json:"name"`
+	Yearly bool   `json:"yearly,omitempty"`
+}
+
+// yearlyHoliday is a holiday repeating every year from its first date.
+type yearlyHoliday struct {
+	from string // DateLayout
+	name string
+}
+
+// HolidayCalendars holds the holiday calendars schedules can subscribe to,
+// keyed by calendar name. It is loaded once at startup and read-only
+// afterwards; yearly holidays are matched against the date asked about, so
+// they keep working however long the bot runs. A nil *HolidayCalendars has
+// no calendars.
+type HolidayCalendars struct {
+	calendars map[string]map[string]string        // name -> date -> holiday name
+	yearly    map[string]map[string]yearlyHoliday // name -> "01-02" -> holiday
+}
+
+func NewHolidayCalendars() *HolidayCalendars {
+	return &HolidayCalendars{
+		calendars: make(map[string]map[string]string),
+		yearly:    make(map[string]map[string]yearlyHoliday),
+	}
+}
+
+// Add merges holidays into the calendar called name, creating it if needed.
+func (hc *HolidayCalendars) Add(name string, holidays []Holiday) error {
+	if !IsValidTag(name) {
+		return fmt.Errorf("nama kalender libur '%s' tidak valid (huruf kecil, angka, _ atau -, maks %d karakter)", name, MaxTagLength)
+	}
+	dates, ok := hc.calendars[name]
+	if !ok {
+		dates = make(map[string]string)
+		hc.calendars[name] = dates
+		hc.yearly[name] = make(map[string]yearlyHoliday)
+	}
+	for _, h := range holidays {
+		if _, err := time.Parse(DateLayout, h.Date); err != nil {
+			return fmt.Errorf("tanggal libur '%s' tidak valid", h.Date)
+		}
+		if !h.Yearly {
+			dates[h.Date] = h.Name
+			continue
+		}
+		monthDay := h.Date[len("2006-"):]
+		if existing, ok := hc.yearly[name][monthDay]; !ok || h.Date < existing.from {
+			hc.yearly[name][monthDay] = yearlyHoliday{from: h.Date, name: h.Name}
+		}
+	}
+	return nil
+}
+
+// lookup returns the holiday of calendar name on date, formatted with
+// DateLayout.
+func (hc *HolidayCalendars) lookup(name, date string) (string, bool) {
+	if holiday, ok := hc.calendars[name][date]; ok {
+		return holiday, true
+	}
+	if y, ok := hc.yearly[name][date[len("2006-"):]]; ok && date >= y.from {
+		return y.name, true
+	}
+	return "", false
+}
+
+// Names returns the calendar names, sorted.
+func (hc *HolidayCalendars) Names() []string {
+	if hc == nil {
+		return nil
+	}
+	names := make([]string, 0, len(hc.calendars))
+	for name := range hc.calendars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether a calendar called name exists.
+func (hc *HolidayCalendars) Has(name string) bool {
+	if hc == nil {
+		return false
+	}
+	_, ok := hc.calendars[name]
+	return ok
+}
+
+// Upcoming returns the holidays of calendar name on or after from, at most
+// n of them.
+func (hc *HolidayCalendars) Upcoming(name string, from time.Time, n int) []Holiday {
+	if hc == nil {
+		return nil
+	}
+	today := from.Format(DateLayout)
+	var result []Holiday
+	for date, holiday := range hc.calendars[name] {
+		if date >= today {
+			result = append(result, Holiday{Date: date, Name: holiday})
+		}
+	}
+	for monthDay, y := range hc.yearly[name] {
+		// 29 February can be up to eight years away
+		for year := from.Year(); year <= from.Year()+8; year++ {
+			date := fmt.Sprintf("%04d-%s", year, monthDay)
+			if _, err := time.Parse(DateLayout, date); err != nil || date < today || date < y.from {
+				continue
+			}
+			if _, dated := hc.calendars[name][date]; !dated {
+				result = append(result, Holiday{Date: date, Name: y.name})
+			}
+			break
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// Holiday returns the name of the holiday that makes s skip its occurrence
+// at the given time, looked up in the calendars s subscribes to.
+func (hc *HolidayCalendars) Holiday(s *Schedule, at time.Time) (string, bool) {
+	if hc == nil || len(s.Holidays) == 0 {
+		return "", false
+	}
+	date := at.Format(DateLayout)
+	for _, name := range s.Holidays {
+		if holiday, ok := hc.lookup(name, date); ok {
+			return holiday, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidayCalendars(t *testing.T) {
+	hc := NewHolidayCalendars()
+	if err := hc.Add("id", []Holiday{
+		{Date: "2026-08-17", Name: "Kemerdekaan", Yearly: true},
+		{Date: "2024-02-29", Name: "Kabisat", Yearly: true},
+		{Date: "2026-10-28", Name: "Cuti bersama"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s := &Schedule{Holidays: []string{"id"}}
+
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2026-08-17", "Kemerdekaan"},
+		// Years after the calendar was loaded still match
+		{"2031-08-17", "Kemerdekaan"},
+		{"2025-08-17", ""},
+		{"2028-02-29", "Kabisat"},
+		{"2026-10-28", "Cuti bersama"},
+		{"2027-10-28", ""},
+		{"2026-08-18", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			at, _ := time.Parse(DateLayout, tt.date)
+			got, ok := hc.Holiday(s, at)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("Holiday(%s) = %q, %v; want %q", tt.date, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestHolidayCalendarsUpcoming(t *testing.T) {
+	hc := NewHolidayCalendars()
+	if err := hc.Add("id", []Holiday{
+		{Date: "2020-01-01", Name: "Tahun Baru", Yearly: true},
+		{Date: "2024-02-29", Name: "Kabisat", Yearly: true},
+		{Date: "2029-12-25", Name: "Natal", Yearly: true},
+		{Date: "2027-01-05", Name: "Cuti bersama"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	want := []string{"2027-01-01", "2027-01-05", "2028-02-29", "2029-12-25"}
+	got := hc.Upcoming("id", from, 10)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Date != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
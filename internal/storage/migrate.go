@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MigrationReport describes what Migrate changed in a data file.
+type MigrationReport struct {
+	Legacy    bool     // file used the old {"userID": [schedule, ...]} layout
+	Schedules int      // schedules written to the migrated file
+	Changes   []string // human readable list of fixed fields
+}
+
+// dayAliases maps day names accepted by older versions to Weekdays.
+var dayAliases = map[string]string{
+	"senin": "Monday", "selasa": "Tuesday", "rabu": "Wednesday", "kamis": "Thursday",
+	"jumat": "Friday", "sabtu": "Saturday", "minggu": "Sunday",
+	"monday": "Monday", "tuesday": "Tuesday", "wednesday": "Wednesday", "thursday": "Thursday",
+	"friday": "Friday", "saturday": "Saturday", "sunday": "Sunday",
+}
+
+// Migrate rewrites the data file at filePath in the current layout. It
+// converts the legacy per-user array layout, normalizes day names and times
+// and fills fields that older versions left empty. The original file is
+// kept next to it with a ".bak" suffix. With dryRun nothing is written.
+func Migrate(filePath string, dryRun bool) (*MigrationReport, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MigrationReport{}
+	schedules := make(map[string]*Schedule)
+
+	var raw map[string]json.RawMessage
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("gagal parse JSON: %w", err)
+		}
+	}
+
+	for key, value := range raw {
+		if strings.HasPrefix(strings.TrimSpace(string(value)), "[") {
+			report.Legacy = true
+
+			var list []*Schedule
+			if err := json.Unmarshal(value, &list); err != nil {
+				return nil, fmt.Errorf("gagal parse jadwal user %s: %w", key, err)
+			}
+			userID, _ := strconv.ParseInt(key, 10, 64)
+			for _, s := range list {
+				if s.UserID == 0 {
+					s.UserID = userID
+				}
+				addMigrated(schedules, s, report)
+			}
+			continue
+		}
+
+		var s Schedule
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, fmt.Errorf("gagal parse jadwal %s: %w", key, err)
+		}
+		if s.ID == "" {
+			s.ID = key
+		}
+		addMigrated(schedules, &s, report)
+	}
+
+	report.Schedules = len(schedules)
+	if dryRun {
+		return report, nil
+	}
+
+	if err := os.WriteFile(filePath+".bak", data, 0644); err != nil {
+		return nil, fmt.Errorf("gagal membuat backup: %w", err)
+	}
+
+	us := &UserSchedules{Schedules: schedules, filePath: filePath}
+	if err := us.Save(); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func addMigrated(schedules map[string]*Schedule, s *Schedule, report *MigrationReport) {
+	us := &UserSchedules{Schedules: schedules}
+	if s.ID == "" || len(s.ID) > MaxIDLength || schedules[s.ID] != nil {
+		old := s.ID
+		s.ID = us.newIDUnlocked(s.UserID)
+		report.Changes = append(report.Changes, fmt.Sprintf("%s: id '%s' diganti menjadi '%s'", s.Title, old, s.ID))
+	}
+
+	if t, ok := normalizeClock(s.Time); ok && t != s.Time {
+		report.Changes = append(report.Changes, fmt.Sprintf("%s: waktu '%s' menjadi '%s'", s.ID, s.Time, t))
+		s.Time = t
+	}
+
+	for i, day := range s.Days {
+		if mapped, ok := dayAliases[strings.ToLower(strings.TrimSpace(day))]; ok && mapped != day {
+			report.Changes = append(report.Changes, fmt.Sprintf("%s: hari '%s' menjadi '%s'", s.ID, day, mapped))
+			s.Days[i] = mapped
+		}
+	}
+
+	if s.ReminderType == "" {
+		s.ReminderType = ReminderRecurring
+		report.Changes = append(report.Changes, fmt.Sprintf("%s: reminder_type diisi '%s'", s.ID, ReminderRecurring))
+	}
+	if s.ReminderTimes == nil {
+		s.ReminderTimes = append([]int(nil), DefaultReminderTimes...)
+		report.Changes = append(report.Changes, fmt.Sprintf("%s: reminder_times diisi %s", s.ID,
+			strings.Trim(strings.Join(strings.Fields(fmt.Sprint(s.ReminderTimes)), ","), "[]")))
+	}
+	if s.ReminderSent == nil {
+		s.ReminderSent = make(map[string]bool)
+	}
+
+	schedules[s.ID] = s
+}
+
+// normalizeClock zero-pads times such as "9:5" to "09:05".
+func normalizeClock(t string) (string, bool) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(t, "%d:%d", &hour, &minute); err != nil {
+		return "", false
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Defaults and bounds of the repeating main notification of priority
+// schedules.
+const (
+	DefaultNagEvery = 5
+	DefaultNagMax   = 6
+	MaxNagEvery     = 120
+	MaxNagCount     = 30
+)
+
+// Nag is the pending repeat of a priority schedule's main notification for
+// the occurrence on Date. Sent counts the repeats already sent and Next is
+// when the following one is due.
+type Nag struct {
+	Date string    `json:"date"`
+	Sent int       `json:"sent"`
+	Next time.Time `json:"next"`
+}
+
+// IsPriority reports whether the main notification of s repeats until it
+// is acknowledged.
+func (s *Schedule) IsPriority() bool {
+	return s.NagEvery > 0
+}
+
+// NagLimit returns how many times the main notification of s repeats at
+// most.
+func (s *Schedule) NagLimit() int {
+	if s.NagMax <= 0 {
+		return DefaultNagMax
+	}
+	return s.NagMax
+}
+
+// StartNag marks the occurrence of schedule id on date as waiting for an
+// acknowledgement, with the first repeat due at next. A nag still pending
+// for an earlier occurrence is replaced.
+func (us *UserSchedules) StartNag(id, date string, next time.Time) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+	schedule.PendingNag = &Nag{Date: date, Next: next}
+	return us.saveUnlocked()
+}
+
+// AdvanceNag records that repeat number sent+1 of the nag on date went out
+// and returns the nag that is still pending, due at next, or nil once the
+// limit is reached. It returns nil without changes when the pending nag is
+// no longer the one the caller saw, for instance because it was
+// acknowledged.
+func (us *UserSchedules) AdvanceNag(id, date string, sent int, next time.Time) (*Nag, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return nil, fmt.Errorf("schedule tidak ditemukan")
+	}
+	nag := schedule.PendingNag
+	if nag == nil || nag.Date != date || nag.Sent != sent {
+		return nil, nil
+	}
+
+	if sent+1 >= schedule.NagLimit() {
+		schedule.PendingNag = nil
+	} else {
+		schedule.PendingNag = &Nag{Date: date, Sent: sent + 1, Next: next}
+	}
+	if err := us.saveUnlocked(); err != nil {
+		return nil, err
+	}
+	if schedule.PendingNag == nil {
+		return nil, nil
+	}
+	pending := *schedule.PendingNag
+	return &pending, nil
+}
+
+// StopNag acknowledges the nag of schedule id on date. It reports whether
+// one was pending.
+func (us *UserSchedules) StopNag(id, date string) (bool, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return false, fmt.Errorf("schedule tidak ditemukan")
+	}
+	if schedule.PendingNag == nil || schedule.PendingNag.Date != date {
+		return false, nil
+	}
+	schedule.PendingNag = nil
+	return true, us.saveUnlocked()
+}
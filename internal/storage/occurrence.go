@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxOccurrenceDays bounds how far NextOccurrences looks past the end of a
+// pause, so a large n or a schedule that never fires again still returns.
+const maxOccurrenceDays = 2 * 366
+
+// NextOccurrences returns up to n times after the given instant at which the
+// main notification of s fires, evaluated in after's location. A "once"
+// schedule fires a single time, so it yields at most one occurrence and none
+// once its main notification was sent. Paused and skipped dates are left
+// out; a schedule paused without a resume date does not fire at all. Only
+// the maxOccurrenceDays days from the later of after and the resume date
+// are searched.
+func (s *Schedule) NextOccurrences(after time.Time, n int) []time.Time {
+	hour, minute, err := ParseClock(s.Time)
+	if err != nil || n <= 0 || (s.Paused && s.PausedUntil == "") {
+		return nil
+	}
+	if s.ReminderType == ReminderOnce {
+		if s.ReminderSent[fmt.Sprintf("%s_main", s.ID)] {
+			return nil
+		}
+		n = 1
+	}
+
+	days := make(map[time.Weekday]bool, len(s.Days))
+	for _, day := range s.Days {
+		if wd, ok := ParseWeekday(day); ok {
+			days[wd] = true
+		}
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	// Start at the resume date rather than walking the paused days
+	y, m, d := after.Date()
+	if s.Paused {
+		if until, err := time.ParseInLocation(DateLayout, s.PausedUntil, after.Location()); err == nil && until.After(after) {
+			y, m, d = until.Date()
+		}
+	}
+
+	var result []time.Time
+	for i := 0; len(result) < n && i < maxOccurrenceDays; i++ {
+		at := time.Date(y, m, d+i, hour, minute, 0, 0, after.Location())
+		if days[at.Weekday()] && at.After(after) && s.FiresOn(at) {
+			result = append(result, at)
+		}
+	}
+
+	return result
+}
+
+// NextOccurrence returns the first firing after the given instant, or false
+// when the schedule will not fire again.
+func (s *Schedule) NextOccurrence(after time.Time) (time.Time, bool) {
+	next := s.NextOccurrences(after, 1)
+	if len(next) == 0 {
+		return time.Time{}, false
+	}
+	return next[0], true
+}
+
+// OccurrencesBetween returns the firings of s in the half-open range
+// [from, to), evaluated in from's location.
+func (s *Schedule) OccurrencesBetween(from, to time.Time) []time.Time {
+	var result []time.Time
+	at, ok := s.NextOccurrence(from.Add(-time.Nanosecond))
+	for ok && at.Before(to) {
+		result = append(result, at)
+		if s.ReminderType == ReminderOnce {
+			break
+		}
+		at, ok = s.NextOccurrence(at)
+	}
+	return result
+}
+
+// ParseWeekday maps a stored day name to its time.Weekday.
+func ParseWeekday(day string) (time.Weekday, bool) {
+	for i, d := range Weekdays {
+		if d == day {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOccurrences(t *testing.T) {
+	// Monday 19 October 2026, 10:00
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change func(s *Schedule)
+		n      int
+		want   []string
+	}{
+		{
+			name: "recurring",
+			n:    3,
+			want: []string{"2026-10-21 09:00", "2026-10-26 09:00", "2026-10-28 09:00"},
+		},
+		{
+			name:   "later today",
+			change: func(s *Schedule) { s.Time = "18:30" },
+			n:      2,
+			want:   []string{"2026-10-19 18:30", "2026-10-21 18:30"},
+		},
+		{
+			name:   "skipped date",
+			change: func(s *Schedule) { s.SkipDates = []string{"2026-10-21"} },
+			n:      2,
+			want:   []string{"2026-10-26 09:00", "2026-10-28 09:00"},
+		},
+		{
+			name:   "paused until a date",
+			change: func(s *Schedule) { s.Pause(time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC)) },
+			n:      2,
+			want:   []string{"2026-10-28 09:00", "2026-11-02 09:00"},
+		},
+		{
+			name:   "paused for years",
+			change: func(s *Schedule) { s.Pause(time.Date(2036, 1, 1, 0, 0, 0, 0, time.UTC)) },
+			n:      1,
+			want:   []string{"2036-01-02 09:00"},
+		},
+		{
+			name:   "paused indefinitely",
+			change: func(s *Schedule) { s.Pause(time.Time{}) },
+			n:      3,
+		},
+		{
+			name:   "once",
+			change: func(s *Schedule) { s.ReminderType = ReminderOnce },
+			n:      3,
+			want:   []string{"2026-10-21 09:00"},
+		},
+		{
+			name: "once already sent",
+			change: func(s *Schedule) {
+				s.ReminderType = ReminderOnce
+				s.ReminderSent["42_1_main"] = true
+			},
+			n: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{
+				ID: "42_1", Title: "Rapat", Time: "09:00", Days: []string{"Monday", "Wednesday"},
+				ReminderType: ReminderRecurring, ReminderSent: map[string]bool{},
+			}
+			if tt.change != nil {
+				tt.change(s)
+			}
+			var got []string
+			for _, at := range s.NextOccurrences(now, tt.n) {
+				got = append(got, at.Format("2006-01-02 15:04"))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNextOccurrencesBounded(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	s := &Schedule{
+		ID: "42_1", Title: "Rapat", Time: "09:00", Days: []string{"Monday"},
+		ReminderType: ReminderRecurring, ReminderSent: map[string]bool{},
+	}
+
+	got := s.NextOccurrences(now, 1000000)
+	if len(got) == 0 || len(got) > maxOccurrenceDays/7+1 {
+		t.Fatalf("got %d occurrences, want at most %d", len(got), maxOccurrenceDays/7+1)
+	}
+	if last := got[len(got)-1]; last.After(now.AddDate(0, 0, maxOccurrenceDays)) {
+		t.Errorf("last occurrence %s is past the search window", last)
+	}
+}
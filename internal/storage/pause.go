@@ -0,0 +1,54 @@
+package storage
+
+import "time"
+
+// IsPausedOn reports whether s is paused on date, formatted with
+// DateLayout. A pause with PausedUntil set ends on that date.
+func (s *Schedule) IsPausedOn(date string) bool {
+	return s.Paused && (s.PausedUntil == "" || date < s.PausedUntil)
+}
+
+// IsSkipped reports whether the occurrence of s on date is skipped.
+func (s *Schedule) IsSkipped(date string) bool {
+	return containsString(s.SkipDates, date)
+}
+
+// FiresOn reports whether s sends its notifications for an occurrence at
+// the given time, i.e. it is neither paused nor skipped on that date.
+func (s *Schedule) FiresOn(at time.Time) bool {
+	date := at.Format(DateLayout)
+	return !s.IsPausedOn(date) && !s.IsSkipped(date)
+}
+
+// Pause stops the notifications of s until the given date, or until it is
+// resumed when until is zero.
+func (s *Schedule) Pause(until time.Time) {
+	s.Paused = true
+	s.PausedUntil = ""
+	if !until.IsZero() {
+		s.PausedUntil = until.Format(DateLayout)
+	}
+}
+
+// Resume ends a pause and forgets skipped occurrences.
+func (s *Schedule) Resume() {
+	s.Paused = false
+	s.PausedUntil = ""
+	s.SkipDates = nil
+}
+
+// Skip marks the occurrence of s on the date of at as skipped. Skipped
+// dates before today are dropped.
+func (s *Schedule) Skip(at, now time.Time) {
+	today := now.Format(DateLayout)
+	var dates []string
+	for _, date := range s.SkipDates {
+		if date >= today {
+			dates = append(dates, date)
+		}
+	}
+	if date := at.Format(DateLayout); !containsString(dates, date) {
+		dates = append(dates, date)
+	}
+	s.SkipDates = dates
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxReminderMinutes bounds reminder offsets to a week before the event.
+const MaxReminderMinutes = 7 * 24 * 60
+
+// DefaultReminderTimes are the reminder offsets of new schedules when the
+// chat did not choose its own: 1 hour, 30 minutes and 5 minutes before.
+var DefaultReminderTimes = []int{60, 30, 5}
+
+// timezoneAliases maps the Indonesian zone abbreviations to their IANA name.
+var timezoneAliases = map[string]string{
+	"WIB":  "Asia/Jakarta",
+	"WITA": "Asia/Makassar",
+	"WIT":  "Asia/Jayapura",
+}
+
+// UserPreferences are the defaults a chat changes with /settings. Empty
+// fields fall back to the bot defaults.
+type UserPreferences struct {
+	// Timezone is the IANA name schedules are evaluated in, such as
+	// "Asia/Makassar"; empty uses TIMEZONE.
+	Timezone string `json:"timezone,omitempty"`
+	Language string `json:"language,omitempty"`
+	// ReminderTimes are the reminder offsets of new schedules in minutes,
+	// largest first; empty uses DefaultReminderTimes.
+	ReminderTimes []int `json:"reminder_times,omitempty"`
+	// ReminderType is the type of schedules created from a sentence when
+	// the sentence does not say; empty means recurring.
+	ReminderType string `json:"reminder_type,omitempty"`
+	// QuietHours is a range such as "22:00-07:00" in which reminders are
+	// held back; it may cross midnight. Empty means none.
+	QuietHours string `json:"quiet_hours,omitempty"`
+	// WorkHours is the range /free looks for free slots in, such as
+	// "08:00-17:00"; empty uses the bot default.
+	WorkHours string `json:"work_hours,omitempty"`
+	// DigestTime is when the daily digest of today's schedules is sent,
+	// such as "07:00"; empty means off. WeeklyDigest turns on the Sunday
+	// evening overview of the coming week.
+	DigestTime   string `json:"digest_time,omitempty"`
+	WeeklyDigest bool   `json:"weekly_digest,omitempty"`
+}
+
+// Validate checks the preferences before they are stored.
+func (p *UserPreferences) Validate() error {
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("zona waktu '%s' tidak dikenal", p.Timezone)
+		}
+	}
+	for _, m := range p.ReminderTimes {
+		if m <= 0 || m > MaxReminderMinutes {
+			return fmt.Errorf("waktu reminder %d menit tidak valid", m)
+		}
+	}
+	if p.ReminderType != "" && p.ReminderType != ReminderOnce && p.ReminderType != ReminderRecurring {
+		return fmt.Errorf("tipe reminder '%s' tidak valid", p.ReminderType)
+	}
+	if p.QuietHours != "" {
+		if _, err := ParseQuietHours(p.QuietHours); err != nil {
+			return err
+		}
+	}
+	if p.WorkHours != "" {
+		if _, err := ParseSlot(p.WorkHours); err != nil {
+			return err
+		}
+	}
+	if p.DigestTime != "" {
+		if _, _, err := ParseClock(p.DigestTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultTimes returns the reminder offsets for new schedules.
+func (p *UserPreferences) DefaultTimes() []int {
+	if len(p.ReminderTimes) == 0 {
+		return append([]int(nil), DefaultReminderTimes...)
+	}
+	return append([]int(nil), p.ReminderTimes...)
+}
+
+// DefaultType returns the type for new schedules that do not say.
+func (p *UserPreferences) DefaultType() string {
+	if p.ReminderType == "" {
+		return ReminderRecurring
+	}
+	return p.ReminderType
+}
+
+// ParseTimezone resolves an IANA name or one of WIB, WITA and WIT.
+func ParseTimezone(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if name, ok := timezoneAliases[strings.ToUpper(text)]; ok {
+		return name, nil
+	}
+	loc, err := time.LoadLocation(text)
+	if err != nil || text == "" || strings.EqualFold(text, "Local") {
+		return "", fmt.Errorf("zona waktu '%s' tidak dikenal, contoh Asia/Jakarta atau WITA", text)
+	}
+	return loc.String(), nil
+}
+
+// ParseQuietHours parses a range such as "22:00-07:00". Unlike ParseSlot the
+// end may come before the start, in which case the range crosses midnight.
+func ParseQuietHours(text string) (Slot, error) {
+	from, to, ok := strings.Cut(strings.ReplaceAll(text, "–", "-"), "-")
+	start, okStart := parseSlotClock(from)
+	end, okEnd := parseSlotClock(to)
+	if !ok || !okStart || !okEnd || start >= MinutesPerDay {
+		return Slot{}, fmt.Errorf("rentang waktu '%s' tidak valid, contoh 22:00-07:00", strings.TrimSpace(text))
+	}
+	if end == start {
+		return Slot{}, fmt.Errorf("jam selesai harus berbeda dengan jam mulai")
+	}
+	return Slot{Start: start, End: end}, nil
+}
+
+// NormalizeReminderTimes drops duplicates and sorts offsets largest first,
+// the order reminders are sent in.
+func NormalizeReminderTimes(minutes []int) []int {
+	seen := make(map[int]bool)
+	var result []int
+	for _, m := range minutes {
+		if !seen[m] {
+			seen[m] = true
+			result = append(result, m)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(result)))
+	return result
+}
+
+// Preferences returns a copy of the preferences of userID.
+func (u *Users) Preferences(userID int64) UserPreferences {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, exists := u.Users[userID]
+	if !exists {
+		return UserPreferences{}
+	}
+	prefs := user.UserPreferences
+	prefs.ReminderTimes = append([]int(nil), prefs.ReminderTimes...)
+	return prefs
+}
+
+// SetPreferences validates and stores the preferences of userID.
+func (u *Users) SetPreferences(userID int64, prefs UserPreferences) error {
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.userUnlocked(userID).UserPreferences = prefs
+	return u.saveUnlocked()
+}
@@ -0,0 +1,34 @@
+package storage
+
+// How a schedule's reminders behave when they fall in the quiet hours of a
+// chat receiving them.
+const (
+	// QuietSilent sends the reminder without sound; it is the default.
+	QuietSilent = "silent"
+	// QuietDelay holds the reminder until the quiet hours end, dropping it
+	// if the event has started by then.
+	QuietDelay = "delay"
+	// QuietSkip drops the reminder.
+	QuietSkip = "skip"
+)
+
+// QuietModes lists the accepted values of Schedule.QuietMode.
+var QuietModes = []string{QuietSilent, QuietDelay, QuietSkip}
+
+// QuietAction returns how s behaves in quiet hours.
+func (s *Schedule) QuietAction() string {
+	if s.QuietMode == "" {
+		return QuietSilent
+	}
+	return s.QuietMode
+}
+
+// Contains reports whether minute, counted from midnight, falls in s. A slot
+// whose End is not after its Start, as returned by ParseQuietHours, crosses
+// midnight.
+func (s Slot) Contains(minute int) bool {
+	if s.End > s.Start {
+		return minute >= s.Start && minute < s.End
+	}
+	return minute >= s.Start || minute < s.End
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// RSVP answers.
+const (
+	RSVPYes   = "yes"
+	RSVPNo    = "no"
+	RSVPMaybe = "maybe"
+)
+
+// RSVPAnswers lists the answers in the order they are shown.
+var RSVPAnswers = []string{RSVPYes, RSVPMaybe, RSVPNo}
+
+// DateLayout formats the occurrence dates that key Schedule.RSVPs.
+const DateLayout = "2006-01-02"
+
+// rsvpRetention is how long answers for past occurrences are kept.
+const rsvpRetention = 60 * 24 * time.Hour
+
+// RSVP is one person's answer for a single occurrence of a schedule.
+type RSVP struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Answer string `json:"answer"`
+}
+
+// IsValidRSVP reports whether answer is one of RSVPAnswers.
+func IsValidRSVP(answer string) bool {
+	for _, a := range RSVPAnswers {
+		if a == answer {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRSVP records the answer of r.UserID for the occurrence of schedule id
+// on date, replacing an earlier answer. Answers for occurrences older than
+// rsvpRetention are dropped.
+func (us *UserSchedules) SetRSVP(id, date string, r RSVP) error {
+	if !IsValidRSVP(r.Answer) {
+		return fmt.Errorf("jawaban '%s' tidak valid", r.Answer)
+	}
+
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+
+	rsvps := make(map[string][]RSVP, len(schedule.RSVPs)+1)
+	cutoff := time.Now().Add(-rsvpRetention).Format(DateLayout)
+	for d, answers := range schedule.RSVPs {
+		if d >= cutoff {
+			rsvps[d] = answers
+		}
+	}
+
+	var answers []RSVP
+	for _, a := range rsvps[date] {
+		if a.UserID != r.UserID {
+			answers = append(answers, a)
+		}
+	}
+	rsvps[date] = append(answers, r)
+
+	// Replace the map instead of mutating it; notifications read it
+	// without holding the lock
+	schedule.RSVPs = rsvps
+	return us.saveUnlocked()
+}
@@ -9,18 +9,52 @@ import (
 	"time"
 )
 
+// Schedule belongs to the chat UserID, which is the user's own ID for
+// private chats and the group's ID for schedules shared in a group. Duration
+// is how many minutes the event lasts from Time, zero when unknown.
+// CreatedBy and CreatorName credit the member who added it. Subscribers are
+// other chats that accepted an invite and receive the same reminders unless
+// they are listed in Muted. RSVPs holds attendance answers per occurrence
+// date. A paused schedule resumes on PausedUntil when it is set; SkipDates
+// lists single occurrences that do not fire, and Holidays names the holiday
+// calendars whose dates are skipped as well. All dates use DateLayout.
+// QuietMode says what happens to reminders falling in a chat's quiet hours,
+// one of QuietModes or empty for QuietSilent. A priority schedule, one with
+// NagEvery set, repeats its main notification every NagEvery minutes, at
+// most NagMax times, until someone acknowledges it; PendingNag tracks the
+// repeat in progress so it survives a restart. FiredDates and DoneDates
+// record the occurrences whose main notification went out and the ones
+// marked done with the Selesai button, for habit statistics.
 type Schedule struct {
-	ID              string    `json:"id"`
-	UserID          int64     `json:"user_id"`
-	Title           string    `json:"title"`
-	Time            string    `json:"time"` 
-	Days            []string  `json:"days"`
-	Note            string    `json:"note"`
-	ReminderType    string    `json:"reminder_type"`    
-	ReminderTimes   []int     `json:"reminder_times"`   
-	ReminderSent    map[string]bool `json:"reminder_sent"` 
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID            string            `json:"id"`
+	UserID        int64             `json:"user_id"`
+	CreatedBy     int64             `json:"created_by,omitempty"`
+	CreatorName   string            `json:"creator_name,omitempty"`
+	Title         string            `json:"title"`
+	Time          string            `json:"time"`
+	Duration      int               `json:"duration,omitempty"`
+	Days          []string          `json:"days"`
+	Note          string            `json:"note"`
+	Tags          []string          `json:"tags,omitempty"`
+	ReminderType  string            `json:"reminder_type"`
+	ReminderTimes []int             `json:"reminder_times"`
+	ReminderSent  map[string]bool   `json:"reminder_sent"`
+	QuietMode     string            `json:"quiet_mode,omitempty"`
+	NagEvery      int               `json:"nag_every,omitempty"`
+	NagMax        int               `json:"nag_max,omitempty"`
+	PendingNag    *Nag              `json:"pending_nag,omitempty"`
+	FiredDates    []string          `json:"fired_dates,omitempty"`
+	DoneDates     []string          `json:"done_dates,omitempty"`
+	Paused        bool              `json:"paused,omitempty"`
+	PausedUntil   string            `json:"paused_until,omitempty"`
+	SkipDates     []string          `json:"skip_dates,omitempty"`
+	Holidays      []string          `json:"holidays,omitempty"`
+	ShareToken    string            `json:"share_token,omitempty"`
+	Subscribers   []int64           `json:"subscribers,omitempty"`
+	Muted         []int64           `json:"muted,omitempty"`
+	RSVPs         map[string][]RSVP `json:"rsvps,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
 }
 
 type UserSchedules struct {
@@ -90,10 +124,23 @@ func (us *UserSchedules) Save() error {
 	return nil
 }
 
+// MaxIDLength keeps schedule IDs short enough to travel in inline button
+// data. Generated IDs, a chat ID and a Unix time, always fit.
+const MaxIDLength = 26
+
+// AddSchedule stores schedule. An empty ID, or one longer than MaxIDLength
+// such as an imported one, is replaced by a generated ID.
 func (us *UserSchedules) AddSchedule(schedule *Schedule) error {
 	us.mu.Lock()
 	defer us.mu.Unlock()
 
+	if schedule.ID == "" || len(schedule.ID) > MaxIDLength {
+		schedule.ID = us.newIDUnlocked(schedule.UserID)
+	}
+	if _, exists := us.Schedules[schedule.ID]; exists {
+		return fmt.Errorf("schedule dengan id '%s' sudah ada", schedule.ID)
+	}
+
 	schedule.CreatedAt = time.Now()
 	schedule.UpdatedAt = time.Now()
 	us.Schedules[schedule.ID] = schedule
@@ -141,6 +188,19 @@ func (us *UserSchedules) GetUserSchedules(userID int64) []*Schedule {
 	return result
 }
 
+// GetAllSchedules returns every stored schedule regardless of owner.
+func (us *UserSchedules) GetAllSchedules() []*Schedule {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	result := make([]*Schedule, 0, len(us.Schedules))
+	for _, schedule := range us.Schedules {
+		result = append(result, schedule)
+	}
+
+	return result
+}
+
 func (us *UserSchedules) GetSchedule(id string) (*Schedule, error) {
 	us.mu.RLock()
 	defer us.mu.RUnlock()
@@ -179,6 +239,19 @@ func (us *UserSchedules) IsTitleExists(userID int64, title string) bool {
 	return false
 }
 
+// newIDUnlocked builds a "userID_timestamp" id that is not yet taken, bumping
+// the timestamp when several schedules are created within the same second.
+func (us *UserSchedules) newIDUnlocked(userID int64) string {
+	ts := time.Now().Unix()
+	for {
+		id := fmt.Sprintf("%d_%d", userID, ts)
+		if _, exists := us.Schedules[id]; !exists {
+			return id
+		}
+		ts++
+	}
+}
+
 func (us *UserSchedules) saveUnlocked() error {
 	data, err := json.MarshalIndent(us.Schedules, "", "  ")
 	if err != nil {
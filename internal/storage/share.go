@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+)
+
+// IsSubscriber reports whether userID receives the reminders of s through
+// an accepted invite.
+func (s *Schedule) IsSubscriber(userID int64) bool {
+	return slices.Contains(s.Subscribers, userID)
+}
+
+// IsMuted reports whether the subscriber userID has muted s.
+func (s *Schedule) IsMuted(userID int64) bool {
+	return slices.Contains(s.Muted, userID)
+}
+
+// ShareToken returns the invite token of schedule id, creating one on first
+// use.
+func (us *UserSchedules) ShareToken(id string) (string, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return "", fmt.Errorf("schedule tidak ditemukan")
+	}
+	if schedule.ShareToken != "" {
+		return schedule.ShareToken, nil
+	}
+	return us.rotateShareTokenUnlocked(schedule)
+}
+
+// ResetShareToken replaces the invite token of schedule id so links and
+// invites sent before stop working. Existing subscribers are kept.
+func (us *UserSchedules) ResetShareToken(id string) (string, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return "", fmt.Errorf("schedule tidak ditemukan")
+	}
+	return us.rotateShareTokenUnlocked(schedule)
+}
+
+func (us *UserSchedules) rotateShareTokenUnlocked(schedule *Schedule) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	schedule.ShareToken = token
+	if err := us.saveUnlocked(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetScheduleByShareToken finds the schedule an invite token belongs to.
+func (us *UserSchedules) GetScheduleByShareToken(token string) (*Schedule, error) {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	if token != "" {
+		for _, schedule := range us.Schedules {
+			if schedule.ShareToken == token {
+				return schedule, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("undangan tidak ditemukan")
+}
+
+// GetSubscribedSchedules returns the schedules of other chats that userID
+// has subscribed to.
+func (us *UserSchedules) GetSubscribedSchedules(userID int64) []*Schedule {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	var result []*Schedule
+	for _, schedule := range us.Schedules {
+		if schedule.IsSubscriber(userID) {
+			result = append(result, schedule)
+		}
+	}
+	return result
+}
+
+// Subscribe adds userID to the subscribers of schedule id.
+func (us *UserSchedules) Subscribe(id string, userID int64) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+	if schedule.UserID == userID || schedule.IsSubscriber(userID) {
+		return nil
+	}
+	schedule.Subscribers = append(slices.Clip(schedule.Subscribers), userID)
+	return us.saveUnlocked()
+}
+
+// Unsubscribe removes userID from the subscribers of schedule id.
+func (us *UserSchedules) Unsubscribe(id string, userID int64) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+	schedule.Subscribers = removeID(schedule.Subscribers, userID)
+	schedule.Muted = removeID(schedule.Muted, userID)
+	return us.saveUnlocked()
+}
+
+// SetMuted mutes or unmutes the reminders of schedule id for the subscriber
+// userID. The owner's reminders are unaffected.
+func (us *UserSchedules) SetMuted(id string, userID int64, muted bool) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	schedule, exists := us.Schedules[id]
+	if !exists || !schedule.IsSubscriber(userID) {
+		return fmt.Errorf("schedule tidak ditemukan")
+	}
+	if muted == schedule.IsMuted(userID) {
+		return nil
+	}
+	if muted {
+		schedule.Muted = append(slices.Clip(schedule.Muted), userID)
+	} else {
+		schedule.Muted = removeID(schedule.Muted, userID)
+	}
+	return us.saveUnlocked()
+}
+
+// removeID returns ids without id. The result is a new slice so readers of
+// the old one are unaffected.
+func removeID(ids []int64, id int64) []int64 {
+	var result []int64
+	for _, i := range ids {
+		if i != id {
+			result = append(result, i)
+		}
+	}
+	return result
+}
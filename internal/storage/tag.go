@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxTagLength keeps tags short enough to travel in inline button data.
+const MaxTagLength = 15
+
+// IsValidTag reports whether tag is a normalized tag: lowercase letters,
+// digits, "_" and "-", at most MaxTagLength bytes.
+func IsValidTag(tag string) bool {
+	if tag == "" || len(tag) > MaxTagLength {
+		return false
+	}
+	for _, r := range tag {
+		letter := unicode.IsLetter(r) && !unicode.IsUpper(r)
+		if !letter && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTags splits text such as "kuliah, #Kerja pribadi" into normalized
+// tags without duplicates.
+func ParseTags(text string) ([]string, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	var tags []string
+	for _, f := range fields {
+		tag := strings.ToLower(strings.TrimLeft(f, "#"))
+		if tag == "" {
+			continue
+		}
+		if !IsValidTag(tag) {
+			return nil, fmt.Errorf("tag '%s' tidak valid (huruf, angka, _ atau -, maks %d karakter)", f, MaxTagLength)
+		}
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// HasTag reports whether s is tagged with tag. Every schedule matches the
+// empty tag, which stands for "no filter".
+func (s *Schedule) HasTag(tag string) bool {
+	return tag == "" || containsString(s.Tags, tag)
+}
+
+// GetUserTags returns the tags used by userID's schedules, sorted.
+func (us *UserSchedules) GetUserTags(userID int64) []string {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	var tags []string
+	for _, schedule := range us.Schedules {
+		if schedule.UserID != userID {
+			continue
+		}
+		for _, tag := range schedule.Tags {
+			if !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
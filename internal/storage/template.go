@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxTemplates bounds the templates a chat keeps so /template fits in one
+// message.
+const MaxTemplates = 20
+
+// TemplateCounter in a template title is replaced by the lowest number that
+// gives an unused title, so "Kuliah {n}" yields "Kuliah 1", "Kuliah 2", ...
+const TemplateCounter = "{n}"
+
+// Template is a set of schedule fields a chat saved to create similar
+// schedules in one step. Title is a pattern that may contain
+// TemplateCounter.
+type Template struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Time          string   `json:"time"`
+	Duration      int      `json:"duration,omitempty"`
+	Days          []string `json:"days"`
+	Note          string   `json:"note,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	ReminderType  string   `json:"reminder_type"`
+	ReminderTimes []int    `json:"reminder_times"`
+}
+
+// NewTemplate captures the fields of s under the title pattern title.
+func NewTemplate(s *Schedule, title string) Template {
+	return Template{
+		Title:         strings.TrimSpace(title),
+		Time:          s.Time,
+		Duration:      s.Duration,
+		Days:          append([]string(nil), s.Days...),
+		Note:          s.Note,
+		Tags:          append([]string(nil), s.Tags...),
+		ReminderType:  s.ReminderType,
+		ReminderTimes: append([]int(nil), s.ReminderTimes...),
+	}
+}
+
+// Schedule builds a new, not yet stored schedule titled title from t.
+func (t *Template) Schedule(title string) *Schedule {
+	return &Schedule{
+		Title:         title,
+		Time:          t.Time,
+		Duration:      t.Duration,
+		Days:          append([]string(nil), t.Days...),
+		Note:          t.Note,
+		Tags:          append([]string(nil), t.Tags...),
+		ReminderType:  t.ReminderType,
+		ReminderTimes: append([]int(nil), t.ReminderTimes...),
+		ReminderSent:  make(map[string]bool),
+	}
+}
+
+// TitleFor returns the title of the n-th schedule made from t.
+func (t *Template) TitleFor(n int) string {
+	return strings.ReplaceAll(t.Title, TemplateCounter, fmt.Sprint(n))
+}
+
+// Validate checks the template the same way as the schedules it creates.
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.TitleFor(1)) == "" {
+		return fmt.Errorf("judul template tidak boleh kosong")
+	}
+	return t.Schedule(t.TitleFor(1)).Validate()
+}
+
+// Templates returns a copy of the templates of userID sorted by title.
+func (u *Users) Templates(userID int64) []Template {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, exists := u.Users[userID]
+	if !exists {
+		return nil
+	}
+	templates := append([]Template(nil), user.Templates...)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Title < templates[j].Title })
+	return templates
+}
+
+// Template returns the template id of userID.
+func (u *Users) Template(userID int64, id string) (Template, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if user, exists := u.Users[userID]; exists {
+		for _, t := range user.Templates {
+			if t.ID == id {
+				return t, true
+			}
+		}
+	}
+	return Template{}, false
+}
+
+// SaveTemplate validates and stores t for userID, replacing the template
+// with the same title if there is one, and returns it with its id.
+func (u *Users) SaveTemplate(userID int64, t Template) (Template, error) {
+	if err := t.Validate(); err != nil {
+		return Template{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user := u.userUnlocked(userID)
+	for i, existing := range user.Templates {
+		if existing.Title == t.Title {
+			t.ID = existing.ID
+			user.Templates[i] = t
+			return t, u.saveUnlocked()
+		}
+	}
+	if len(user.Templates) >= MaxTemplates {
+		return Template{}, fmt.Errorf("maksimal %d template, hapus salah satu terlebih dahulu", MaxTemplates)
+	}
+
+	id, err := newToken()
+	if err != nil {
+		return Template{}, err
+	}
+	t.ID = id[:12]
+	user.Templates = append(user.Templates, t)
+	return t, u.saveUnlocked()
+}
+
+// DeleteTemplate removes the template id of userID. It reports false when
+// there was no such template.
+func (u *Users) DeleteTemplate(userID int64, id string) (bool, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, exists := u.Users[userID]
+	if !exists {
+		return false, nil
+	}
+	for i, t := range user.Templates {
+		if t.ID == id {
+			user.Templates = append(user.Templates[:i:i], user.Templates[i+1:]...)
+			return true, u.saveUnlocked()
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// User holds per-user data that does not belong to a single schedule.
+type User struct {
+	ID        int64  `json:"id"`
+	FeedToken string `json:"feed_token,omitempty"`
+	Username  string `json:"username,omitempty"`
+	// MutedTags silences the reminders of every schedule carrying one of
+	// these tags in this chat.
+	MutedTags []string `json:"muted_tags,omitempty"`
+	// Templates are the schedule templates saved with /template.
+	Templates []Template `json:"templates,omitempty"`
+	UserPreferences
+}
+
+// Users is the JSON backed store of User records.
+type Users struct {
+	Users    map[int64]*User `json:"users"`
+	mu       sync.RWMutex
+	filePath string
+}
+
+func NewUsers(filePath string) (*Users, error) {
+	u := &Users{
+		Users:    make(map[int64]*User),
+		filePath: filePath,
+	}
+
+	// Ensure directory exists
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori: %w", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &u.Users); err != nil {
+			return nil, fmt.Errorf("gagal parse JSON: %w", err)
+		}
+	}
+
+	return u, nil
+}
+
+// FeedToken returns the calendar feed token of userID, creating one on
+// first use.
+func (u *Users) FeedToken(userID int64) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user := u.userUnlocked(userID)
+	if user.FeedToken != "" {
+		return user.FeedToken, nil
+	}
+	return u.rotateFeedTokenUnlocked(user)
+}
+
+// ResetFeedToken replaces the feed token of userID so the old feed URL stops
+// working.
+func (u *Users) ResetFeedToken(userID int64) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.rotateFeedTokenUnlocked(u.userUnlocked(userID))
+}
+
+// UserByFeedToken finds the owner of a feed token.
+func (u *Users) UserByFeedToken(token string) (int64, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if token == "" {
+		return 0, false
+	}
+	for _, user := range u.Users {
+		if user.FeedToken == token {
+			return user.ID, true
+		}
+	}
+	return 0, false
+}
+
+// Language returns the interface language code chosen for userID, or an
+// empty string if none has been stored yet.
+func (u *Users) Language(userID int64) string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if user, exists := u.Users[userID]; exists {
+		return user.Language
+	}
+	return ""
+}
+
+// SetLanguage stores the interface language code of userID.
+func (u *Users) SetLanguage(userID int64, lang string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user := u.userUnlocked(userID)
+	if user.Language == lang {
+		return nil
+	}
+	user.Language = lang
+	return u.saveUnlocked()
+}
+
+// SetUsername remembers the Telegram username of userID so schedules can be
+// shared with them by @username.
+func (u *Users) SetUsername(userID int64, username string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if user, exists := u.Users[userID]; exists && user.Username == username {
+		return nil
+	}
+	u.userUnlocked(userID).Username = username
+	return u.saveUnlocked()
+}
+
+// UserByUsername finds a user who has talked to the bot by their username,
+// with or without the leading "@".
+func (u *Users) UserByUsername(username string) (int64, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	username = strings.TrimPrefix(username, "@")
+	if username == "" {
+		return 0, false
+	}
+	for _, user := range u.Users {
+		if strings.EqualFold(user.Username, username) {
+			return user.ID, true
+		}
+	}
+	return 0, false
+}
+
+// MutedTags returns the tags userID has muted.
+func (u *Users) MutedTags(userID int64) []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if user, exists := u.Users[userID]; exists {
+		return append([]string(nil), user.MutedTags...)
+	}
+	return nil
+}
+
+// SetTagMuted mutes or unmutes the reminders tagged tag for userID.
+func (u *Users) SetTagMuted(userID int64, tag string, muted bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user := u.userUnlocked(userID)
+	if containsString(user.MutedTags, tag) == muted {
+		return nil
+	}
+
+	var tags []string
+	for _, t := range user.MutedTags {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	if muted {
+		tags = append(tags, tag)
+		sort.Strings(tags)
+	}
+	user.MutedTags = tags
+	return u.saveUnlocked()
+}
+
+// WorkHours returns the working hours userID set, or "" for the default.
+func (u *Users) WorkHours(userID int64) string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if user, exists := u.Users[userID]; exists {
+		return user.WorkHours
+	}
+	return ""
+}
+
+// SetWorkHours stores the working hours of userID; "" restores the default.
+func (u *Users) SetWorkHours(userID int64, hours string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.userUnlocked(userID).WorkHours = hours
+	return u.saveUnlocked()
+}
+
+// Digest returns the daily digest time of userID, empty when off, and
+// whether the weekly overview is on.
+func (u *Users) Digest(userID int64) (daily string, weekly bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if user, exists := u.Users[userID]; exists {
+		return user.DigestTime, user.WeeklyDigest
+	}
+	return "", false
+}
+
+// SetDigest stores the digest settings of userID.
+func (u *Users) SetDigest(userID int64, daily string, weekly bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user := u.userUnlocked(userID)
+	user.DigestTime = daily
+	user.WeeklyDigest = weekly
+	return u.saveUnlocked()
+}
+
+// DigestUsers returns the users with a daily or weekly digest turned on.
+func (u *Users) DigestUsers() []int64 {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var ids []int64
+	for id, user := range u.Users {
+		if user.DigestTime != "" || user.WeeklyDigest {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (u *Users) rotateFeedTokenUnlocked(user *User) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	user.FeedToken = token
+	if err := u.saveUnlocked(); err != nil {
+		return "", err
+	}
+	return user.FeedToken, nil
+}
+
+// newToken returns a random hex token for feed and invite links.
+func newToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("gagal membuat token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// userUnlocked returns the record of userID, adding an empty one if needed.
+func (u *Users) userUnlocked(userID int64) *User {
+	user, exists := u.Users[userID]
+	if !exists {
+		user = &User{ID: userID}
+		u.Users[userID] = user
+	}
+	return user
+}
+
+func (u *Users) saveUnlocked() error {
+	data, err := json.MarshalIndent(u.Users, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(u.filePath, data, 0644); err != nil {
+		return fmt.Errorf("gagal menyimpan file: %w", err)
+	}
+
+	return nil
+}
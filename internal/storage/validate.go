@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Weekdays lists the day names accepted in Schedule.Days, in cron order.
+var Weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+const (
+	ReminderOnce      = "once"
+	ReminderRecurring = "recurring"
+)
+
+// IsValidDay reports whether day is one of Weekdays.
+func IsValidDay(day string) bool {
+	for _, d := range Weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock splits a stored "HH:MM" time into hour and minute.
+func ParseClock(t string) (hour, minute int, err error) {
+	parts := strings.Split(t, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, 0, fmt.Errorf("format waktu '%s' harus HH:MM", t)
+	}
+
+	hour, err = strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("jam '%s' tidak valid", parts[0])
+	}
+	minute, err = strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("menit '%s' tidak valid", parts[1])
+	}
+
+	return hour, minute, nil
+}
+
+// Validate checks the fields the scheduler relies on to build cron specs.
+func (s *Schedule) Validate() error {
+	if strings.TrimSpace(s.Title) == "" {
+		return fmt.Errorf("judul tidak boleh kosong")
+	}
+	if _, _, err := ParseClock(s.Time); err != nil {
+		return err
+	}
+	if len(s.Days) == 0 {
+		return fmt.Errorf("minimal satu hari harus dipilih")
+	}
+	for _, day := range s.Days {
+		if !IsValidDay(day) {
+			return fmt.Errorf("hari '%s' tidak valid", day)
+		}
+	}
+	if hour, minute, err := ParseClock(s.Time); err == nil && (s.Duration < 0 || hour*60+minute+s.Duration > MinutesPerDay) {
+		return fmt.Errorf("durasi %d menit tidak valid: acara harus selesai sebelum tengah malam", s.Duration)
+	}
+	if s.PausedUntil != "" {
+		if _, err := time.Parse(DateLayout, s.PausedUntil); err != nil {
+			return fmt.Errorf("tanggal lanjut '%s' tidak valid", s.PausedUntil)
+		}
+	}
+	for _, date := range s.SkipDates {
+		if _, err := time.Parse(DateLayout, date); err != nil {
+			return fmt.Errorf("tanggal lewati '%s' tidak valid", date)
+		}
+	}
+	for _, name := range s.Holidays {
+		if !IsValidTag(name) {
+			return fmt.Errorf("kalender libur '%s' tidak valid", name)
+		}
+	}
+	for _, tag := range s.Tags {
+		if !IsValidTag(tag) {
+			return fmt.Errorf("tag '%s' tidak valid", tag)
+		}
+	}
+	if s.ReminderType != ReminderOnce && s.ReminderType != ReminderRecurring {
+		return fmt.Errorf("tipe reminder '%s' tidak valid", s.ReminderType)
+	}
+	if s.QuietMode != "" && !containsString(QuietModes, s.QuietMode) {
+		return fmt.Errorf("mode jam tenang '%s' tidak valid", s.QuietMode)
+	}
+	if s.NagEvery < 0 || s.NagEvery > MaxNagEvery {
+		return fmt.Errorf("jeda pengulangan %d menit tidak valid (maksimal %d)", s.NagEvery, MaxNagEvery)
+	}
+	if s.NagMax < 0 || s.NagMax > MaxNagCount {
+		return fmt.Errorf("jumlah pengulangan %d tidak valid (maksimal %d)", s.NagMax, MaxNagCount)
+	}
+	for _, m := range s.ReminderTimes {
+		if m <= 0 {
+			return fmt.Errorf("waktu reminder %d menit tidak valid", m)
+		}
+	}
+	return nil
+}
@@ -2,12 +2,30 @@ package main
 
 import (
 	"log"
+	"os"
+	"strings"
 
 	"turschedule/config"
+	"turschedule/internal/api"
 	"turschedule/internal/bot"
+	"turschedule/internal/cli"
+	"turschedule/internal/ical"
+	"turschedule/internal/storage"
 )
 
 func main() {
+	// Offline subcommands work on DB_PATH without a Telegram token
+	if len(os.Args) > 1 {
+		cfg, err := config.LoadOffline()
+		if err != nil {
+			log.Fatalf("Gagal memuat config: %v\n", err)
+		}
+		if err := cli.Run(cfg, os.Args[1:], os.Stdout); err != nil {
+			log.Fatalf("%v\n", err)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -16,13 +34,53 @@ func main() {
 
 	log.Println("🚀 Memulai Schedule Bot...")
 
+	stor, err := storage.NewUserSchedules(cfg.DBPath)
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi storage: %v\n", err)
+	}
+	users, err := storage.NewUsers(cfg.UsersDBPath)
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi storage user: %v\n", err)
+	}
+	holidays, err := ical.LoadHolidayCalendars(cfg.HolidaysDir, cfg.Location)
+	if err != nil {
+		log.Fatalf("Gagal memuat kalender libur: %v\n", err)
+	}
+	if names := holidays.Names(); len(names) > 0 {
+		log.Printf("Kalender libur dimuat: %s\n", strings.Join(names, ", "))
+	}
+
 	// Create bot
-	b, err := bot.NewBot(cfg.TelegramBotToken, cfg.DBPath)
+	b, err := bot.NewBot(cfg, stor, users, holidays)
 	if err != nil {
 		log.Fatalf("Gagal membuat bot: %v\n", err)
 	}
 
 	log.Println("✅ Bot berhasil dibuat")
+
+	// Register stored schedules before the API can reschedule them
+	b.Load()
+
+	// Start admin API
+	if cfg.AdminAPIToken != "" {
+		server := api.NewServer(stor, users, b, cfg.AdminAPIToken)
+		go func() {
+			if err := server.ListenAndServe(cfg.AdminAPIAddr); err != nil {
+				log.Fatalf("Admin API berhenti: %v\n", err)
+			}
+		}()
+	}
+
+	// Serve calendar feeds on their own listener
+	if cfg.PublicURL != "" {
+		feed := api.NewFeed(stor, users, holidays, cfg.Location)
+		go func() {
+			if err := feed.ListenAndServe(cfg.FeedAddr); err != nil {
+				log.Fatalf("Feed kalender berhenti: %v\n", err)
+			}
+		}()
+	}
+
 	log.Println("⏳ Bot sedang mendengarkan pesan...")
 
 	// Start listening